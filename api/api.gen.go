@@ -12,100 +12,1161 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for BookingStatus.
+const (
+	BookingStatusActive    BookingStatus = "active"
+	BookingStatusCancelled BookingStatus = "cancelled"
+)
+
+// Defines values for BookingPageStatus.
+const (
+	BookingPageStatusFailed  BookingPageStatus = "Failed"
+	BookingPageStatusSuccess BookingPageStatus = "Success"
+)
+
+// Defines values for ClassPageStatus.
+const (
+	ClassPageStatusFailed  ClassPageStatus = "Failed"
+	ClassPageStatusSuccess ClassPageStatus = "Success"
+)
+
+// Defines values for MemberStatus.
+const (
+	MemberStatusActive   MemberStatus = "active"
+	MemberStatusInactive MemberStatus = "inactive"
+)
+
+// Defines values for MemberListStatus.
+const (
+	MemberListStatusFailed  MemberListStatus = "Failed"
+	MemberListStatusSuccess MemberListStatus = "Success"
+)
+
+// Defines values for MemberRequestStatus.
+const (
+	MemberRequestStatusActive   MemberRequestStatus = "active"
+	MemberRequestStatusInactive MemberRequestStatus = "inactive"
+)
+
+// Defines values for PageResponseStatus.
+const (
+	PageResponseStatusFailed  PageResponseStatus = "Failed"
+	PageResponseStatusSuccess PageResponseStatus = "Success"
+)
+
+// Defines values for RecurrenceDays.
+const (
+	Fri RecurrenceDays = "fri"
+	Mon RecurrenceDays = "mon"
+	Sat RecurrenceDays = "sat"
+	Sun RecurrenceDays = "sun"
+	Thu RecurrenceDays = "thu"
+	Tue RecurrenceDays = "tue"
+	Wed RecurrenceDays = "wed"
+)
+
+// Defines values for ResponseStatus.
+const (
+	Failed  ResponseStatus = "Failed"
+	Success ResponseStatus = "Success"
+)
+
+// Defines values for WaitlistEntryStatus.
+const (
+	Promoted WaitlistEntryStatus = "promoted"
+	Waiting  WaitlistEntryStatus = "waiting"
+)
+
+// Defines values for GetBookingsParamsStatus.
+const (
+	GetBookingsParamsStatusActive    GetBookingsParamsStatus = "active"
+	GetBookingsParamsStatusCancelled GetBookingsParamsStatus = "cancelled"
+)
+
+// Defines values for GetClassesParamsSort.
+const (
+	Capacity       GetClassesParamsSort = "capacity"
+	MinusCapacity  GetClassesParamsSort = "-capacity"
+	MinusName      GetClassesParamsSort = "-name"
+	MinusStartDate GetClassesParamsSort = "-start_date"
+	Name           GetClassesParamsSort = "name"
+	StartDate      GetClassesParamsSort = "start_date"
+)
+
+// Booking defines model for Booking.
+type Booking struct {
+	// CancellationReason Why the booking was cancelled
+	CancellationReason *string `json:"cancellation_reason,omitempty"`
+
+	// CancelledAt When the booking was cancelled
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+
+	// ClassId The ID of the class booked
+	ClassId *string `json:"class_id,omitempty"`
+
+	// ClassName The name of the class booked
+	ClassName *string `json:"class_name,omitempty"`
+
+	// Date The specific date of the booking
+	Date *openapi_types.Date `json:"date,omitempty"`
+
+	// Id The ID of the booking
+	Id *string `json:"id,omitempty"`
+
+	// MemberId The ID of the member, not set on bookings made before members existed
+	MemberId *string `json:"member_id,omitempty"`
+
+	// MemberName The name of the member
+	MemberName *string `json:"member_name,omitempty"`
+
+	// StartsAt The start of the booked session, only set for classes with a start time
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+
+	// Status Whether the booking is active or cancelled
+	Status *BookingStatus `json:"status,omitempty"`
+}
+
+// BookingStatus Whether the booking is active or cancelled
+type BookingStatus string
+
+// BookingPage defines model for BookingPage.
+type BookingPage struct {
+	Data []Booking `json:"data"`
+
+	// Message The error, empty on success
+	Message *string `json:"message,omitempty"`
+
+	// NextCursor The cursor of the next page, absent on the last page
+	NextCursor *string            `json:"next_cursor,omitempty"`
+	Status     *BookingPageStatus `json:"status,omitempty"`
+	StatusCode *int               `json:"statusCode,omitempty"`
+}
+
+// BookingPageStatus defines model for BookingPage.Status.
+type BookingPageStatus string
+
 // BookingRequest defines model for BookingRequest.
 type BookingRequest struct {
 	// ClassId The ID of the class booked
 	ClassId *string `json:"class_id,omitempty"`
 
-	// ClassName The name of the class booked
+	// ClassName Ignored on input, the class name is taken from the class referenced by class_id
 	ClassName *string `json:"class_name,omitempty"`
 
-	// Date The specific date of the booking
-	Date *openapi_types.Date `json:"date,omitempty"`
+	// Date The specific date of the booking, optional when starts_at is given
+	Date *openapi_types.Date `json:"date,omitempty"`
+
+	// MemberId The ID of the member booking the class, who must be active
+	MemberId *string `json:"member_id,omitempty"`
+
+	// MemberName Ignored on input, the member name is taken from the member referenced by member_id
+	MemberName *string `json:"member_name,omitempty"`
+
+	// StartsAt The start of the session to book, for classes with a start time
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+
+	// Waitlist Join the waitlist instead of failing when the class is full on that date
+	Waitlist *bool `json:"waitlist,omitempty"`
+}
+
+// Class defines model for Class.
+type Class struct {
+	// Capacity The number of members that can book the class on each date
+	Capacity *int `json:"capacity,omitempty"`
+
+	// DurationMinutes The length of each session in minutes
+	DurationMinutes *int `json:"duration_minutes,omitempty"`
+
+	// EndDate The last date the class runs
+	EndDate *openapi_types.Date `json:"end_date,omitempty"`
+
+	// Id The ID of the class
+	Id *string `json:"id,omitempty"`
+
+	// Name The name of the class
+	Name *string `json:"name,omitempty"`
+
+	// Recurrence Weekly schedule of a class. A class without one runs every day between its start and end dates.
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+
+	// StartDate The first date the class runs
+	StartDate *openapi_types.Date `json:"start_date,omitempty"`
+
+	// StartTime The local start time of each session as HH:MM
+	StartTime *string `json:"start_time,omitempty"`
+
+	// Timezone The IANA timezone of start_time
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// ClassPage defines model for ClassPage.
+type ClassPage struct {
+	Data []Class `json:"data"`
+
+	// Message The error, empty on success
+	Message *string `json:"message,omitempty"`
+
+	// NextCursor The cursor of the next page, absent on the last page
+	NextCursor *string          `json:"next_cursor,omitempty"`
+	Status     *ClassPageStatus `json:"status,omitempty"`
+	StatusCode *int             `json:"statusCode,omitempty"`
+}
+
+// ClassPageStatus defines model for ClassPage.Status.
+type ClassPageStatus string
+
+// ClassRequest defines model for ClassRequest.
+type ClassRequest struct {
+	Capacity        *int                `json:"capacity,omitempty"`
+	DurationMinutes *int                `json:"duration_minutes,omitempty"`
+	EndDate         *openapi_types.Date `json:"end_date,omitempty"`
+	Name            *string             `json:"name,omitempty"`
+
+	// Recurrence Weekly schedule of a class. A class without one runs every day between its start and end dates.
+	Recurrence *Recurrence         `json:"recurrence,omitempty"`
+	StartDate  *openapi_types.Date `json:"start_date,omitempty"`
+	StartTime  *string             `json:"start_time,omitempty"`
+	Timezone   *string             `json:"timezone,omitempty"`
+}
+
+// Member defines model for Member.
+type Member struct {
+	// CreatedAt When the member was created
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Email The email of the member, unique and stored lowercase
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// Id The ID of the member
+	Id *string `json:"id,omitempty"`
+
+	// Name The full name of the member
+	Name *string `json:"name,omitempty"`
+
+	// Status Only active members can book classes
+	Status *MemberStatus `json:"status,omitempty"`
+}
+
+// MemberStatus Only active members can book classes
+type MemberStatus string
+
+// MemberList defines model for MemberList.
+type MemberList struct {
+	Data []Member `json:"data"`
+
+	// Message The error, empty on success
+	Message    *string           `json:"message,omitempty"`
+	Status     *MemberListStatus `json:"status,omitempty"`
+	StatusCode *int              `json:"statusCode,omitempty"`
+}
+
+// MemberListStatus defines model for MemberList.Status.
+type MemberListStatus string
+
+// MemberRequest defines model for MemberRequest.
+type MemberRequest struct {
+	Email  *openapi_types.Email `json:"email,omitempty"`
+	Name   *string              `json:"name,omitempty"`
+	Status *MemberRequestStatus `json:"status,omitempty"`
+}
+
+// MemberRequestStatus defines model for MemberRequest.Status.
+type MemberRequestStatus string
+
+// Occurrence defines model for Occurrence.
+type Occurrence struct {
+	// Booked The number of active bookings for the session
+	Booked *int `json:"booked,omitempty"`
+
+	// Capacity The capacity of the session
+	Capacity *int `json:"capacity,omitempty"`
+
+	// ClassId The ID of the class
+	ClassId *string `json:"class_id,omitempty"`
+
+	// Date The date of the session
+	Date *openapi_types.Date `json:"date,omitempty"`
+
+	// EndsAt When the session ends, only set for classes with a start time
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+
+	// Remaining The number of spots still available
+	Remaining *int `json:"remaining,omitempty"`
+
+	// StartsAt When the session starts, only set for classes with a start time
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+}
+
+// PageResponse defines model for PageResponse.
+type PageResponse struct {
+	// Message The error, empty on success
+	Message *string `json:"message,omitempty"`
+
+	// NextCursor The cursor of the next page, absent on the last page
+	NextCursor *string             `json:"next_cursor,omitempty"`
+	Status     *PageResponseStatus `json:"status,omitempty"`
+	StatusCode *int                `json:"statusCode,omitempty"`
+}
+
+// PageResponseStatus defines model for PageResponse.Status.
+type PageResponseStatus string
+
+// Recurrence Weekly schedule of a class. A class without one runs every day between its start and end dates.
+type Recurrence struct {
+	// Days The days of the week the class runs
+	Days []RecurrenceDays `json:"days"`
+
+	// Exceptions Dates the class does not run, such as holidays
+	Exceptions *[]openapi_types.Date `json:"exceptions,omitempty"`
+}
+
+// RecurrenceDays defines model for Recurrence.Days.
+type RecurrenceDays string
+
+// Response The envelope of every response. List endpoints answer 200 with an empty data list when nothing matches, 404 is reserved for missing single resources.
+type Response struct {
+	// Message The error, empty on success
+	Message    *string         `json:"message,omitempty"`
+	Status     *ResponseStatus `json:"status,omitempty"`
+	StatusCode *int            `json:"statusCode,omitempty"`
+}
+
+// ResponseStatus defines model for Response.Status.
+type ResponseStatus string
+
+// WaitlistEntry defines model for WaitlistEntry.
+type WaitlistEntry struct {
+	// BookingId The booking created when the member was promoted
+	BookingId *string `json:"booking_id,omitempty"`
+
+	// ClassId The ID of the class
+	ClassId *string `json:"class_id,omitempty"`
+
+	// CreatedAt When the member joined the waitlist
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Date The date of the class occurrence
+	Date *openapi_types.Date `json:"date,omitempty"`
+
+	// Id The ID of the waitlist entry
+	Id *string `json:"id,omitempty"`
+
+	// MemberId The ID of the member
+	MemberId *string `json:"member_id,omitempty"`
+
+	// MemberName The name of the member
+	MemberName *string `json:"member_name,omitempty"`
+
+	// Position The 1-based place of the member in the queue
+	Position *int `json:"position,omitempty"`
+
+	// Status Whether the member is still waiting or was promoted to a booking
+	Status *WaitlistEntryStatus `json:"status,omitempty"`
+}
+
+// WaitlistEntryStatus Whether the member is still waiting or was promoted to a booking
+type WaitlistEntryStatus string
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// Force defines model for Force.
+type Force = bool
+
+// Limit defines model for Limit.
+type Limit = int
+
+// GetBookingsParams defines parameters for GetBookings.
+type GetBookingsParams struct {
+	// Limit The maximum number of items in the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The next_cursor of the previous page, omitted for the first page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// ClassId Only bookings of this class
+	ClassId *string `form:"class_id,omitempty" json:"class_id,omitempty"`
+
+	// Member Only bookings of this member, by member ID. Any other value matches the member name case-insensitively, for bookings made before members existed.
+	Member *string `form:"member,omitempty" json:"member,omitempty"`
+
+	// Date Only bookings on this date
+	Date *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
+
+	// From Only bookings on or after this date
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only bookings on or before this date
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Status Only bookings with this status
+	Status *GetBookingsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetBookingsParamsStatus defines parameters for GetBookings.
+type GetBookingsParamsStatus string
+
+// CancelBookingParams defines parameters for CancelBooking.
+type CancelBookingParams struct {
+	// Reason Why the booking is being cancelled
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// GetClassesParams defines parameters for GetClasses.
+type GetClassesParams struct {
+	// Limit The maximum number of items in the page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The next_cursor of the previous page, omitted for the first page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Name Only classes whose name contains this text, case-insensitively
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// ActiveOn Only classes with a session on this date. Cannot be combined with from or to.
+	ActiveOn *openapi_types.Date `form:"active_on,omitempty" json:"active_on,omitempty"`
+
+	// From Only classes running on or after this date. With to, only classes with a session between from and to, at most 366 days apart.
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only classes running on or before this date
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// MinSpots Only classes with a session that has at least this many spots left, in the active_on date or the from-to window, which are then required
+	MinSpots *int `form:"min_spots,omitempty" json:"min_spots,omitempty"`
+
+	// Sort Sort order, ID by default
+	Sort *GetClassesParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// GetClassesParamsSort defines parameters for GetClasses.
+type GetClassesParamsSort string
+
+// DeleteClassParams defines parameters for DeleteClass.
+type DeleteClassParams struct {
+	// Force Cancel the bookings affected by the change instead of rejecting it
+	Force *Force `form:"force,omitempty" json:"force,omitempty"`
+}
+
+// PatchClassParams defines parameters for PatchClass.
+type PatchClassParams struct {
+	// Force Cancel the bookings affected by the change instead of rejecting it
+	Force *Force `form:"force,omitempty" json:"force,omitempty"`
+}
+
+// UpdateClassParams defines parameters for UpdateClass.
+type UpdateClassParams struct {
+	// Force Cancel the bookings affected by the change instead of rejecting it
+	Force *Force `form:"force,omitempty" json:"force,omitempty"`
+}
+
+// GetClassOccurrencesParams defines parameters for GetClassOccurrences.
+type GetClassOccurrencesParams struct {
+	// From First date to list, defaults to today or the class start date if later
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last date to list, defaults to four weeks after from (at most 366 days after it)
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// BookClassJSONRequestBody defines body for BookClass for application/json ContentType.
+type BookClassJSONRequestBody = BookingRequest
+
+// CreateClassJSONRequestBody defines body for CreateClass for application/json ContentType.
+type CreateClassJSONRequestBody = ClassRequest
+
+// PatchClassJSONRequestBody defines body for PatchClass for application/json ContentType.
+type PatchClassJSONRequestBody = ClassRequest
+
+// UpdateClassJSONRequestBody defines body for UpdateClass for application/json ContentType.
+type UpdateClassJSONRequestBody = ClassRequest
+
+// CreateMemberJSONRequestBody defines body for CreateMember for application/json ContentType.
+type CreateMemberJSONRequestBody = MemberRequest
+
+// PatchMemberJSONRequestBody defines body for PatchMember for application/json ContentType.
+type PatchMemberJSONRequestBody = MemberRequest
+
+// UpdateMemberJSONRequestBody defines body for UpdateMember for application/json ContentType.
+type UpdateMemberJSONRequestBody = MemberRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get bookings
+	// (GET /bookings)
+	GetBookings(w http.ResponseWriter, r *http.Request, params GetBookingsParams)
+	// Book a class
+	// (POST /bookings)
+	BookClass(w http.ResponseWriter, r *http.Request)
+	// Cancel a booking
+	// (DELETE /bookings/{id})
+	CancelBooking(w http.ResponseWriter, r *http.Request, id string, params CancelBookingParams)
+	// Get all classes
+	// (GET /classes)
+	GetClasses(w http.ResponseWriter, r *http.Request, params GetClassesParams)
+	// Create a new class
+	// (POST /classes)
+	CreateClass(w http.ResponseWriter, r *http.Request)
+	// Delete a class
+	// (DELETE /classes/{id})
+	DeleteClass(w http.ResponseWriter, r *http.Request, id string, params DeleteClassParams)
+	// Get a class by ID
+	// (GET /classes/{id})
+	GetClass(w http.ResponseWriter, r *http.Request, id string)
+	// Partially update a class
+	// (PATCH /classes/{id})
+	PatchClass(w http.ResponseWriter, r *http.Request, id string, params PatchClassParams)
+	// Replace a class
+	// (PUT /classes/{id})
+	UpdateClass(w http.ResponseWriter, r *http.Request, id string, params UpdateClassParams)
+	// List the sessions of a class
+	// (GET /classes/{id}/occurrences)
+	GetClassOccurrences(w http.ResponseWriter, r *http.Request, id string, params GetClassOccurrencesParams)
+	// Get the waitlist of a class occurrence
+	// (GET /classes/{id}/occurrences/{date}/waitlist)
+	GetWaitlist(w http.ResponseWriter, r *http.Request, id string, date openapi_types.Date)
+	// Get all members
+	// (GET /members)
+	GetMembers(w http.ResponseWriter, r *http.Request)
+	// Create a new member
+	// (POST /members)
+	CreateMember(w http.ResponseWriter, r *http.Request)
+	// Delete a member
+	// (DELETE /members/{id})
+	DeleteMember(w http.ResponseWriter, r *http.Request, id string)
+	// Get a member by ID
+	// (GET /members/{id})
+	GetMember(w http.ResponseWriter, r *http.Request, id string)
+	// Partially update a member
+	// (PATCH /members/{id})
+	PatchMember(w http.ResponseWriter, r *http.Request, id string)
+	// Replace a member
+	// (PUT /members/{id})
+	UpdateMember(w http.ResponseWriter, r *http.Request, id string)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Get bookings
+// (GET /bookings)
+func (_ Unimplemented) GetBookings(w http.ResponseWriter, r *http.Request, params GetBookingsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Book a class
+// (POST /bookings)
+func (_ Unimplemented) BookClass(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel a booking
+// (DELETE /bookings/{id})
+func (_ Unimplemented) CancelBooking(w http.ResponseWriter, r *http.Request, id string, params CancelBookingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all classes
+// (GET /classes)
+func (_ Unimplemented) GetClasses(w http.ResponseWriter, r *http.Request, params GetClassesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new class
+// (POST /classes)
+func (_ Unimplemented) CreateClass(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a class
+// (DELETE /classes/{id})
+func (_ Unimplemented) DeleteClass(w http.ResponseWriter, r *http.Request, id string, params DeleteClassParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a class by ID
+// (GET /classes/{id})
+func (_ Unimplemented) GetClass(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Partially update a class
+// (PATCH /classes/{id})
+func (_ Unimplemented) PatchClass(w http.ResponseWriter, r *http.Request, id string, params PatchClassParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace a class
+// (PUT /classes/{id})
+func (_ Unimplemented) UpdateClass(w http.ResponseWriter, r *http.Request, id string, params UpdateClassParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the sessions of a class
+// (GET /classes/{id}/occurrences)
+func (_ Unimplemented) GetClassOccurrences(w http.ResponseWriter, r *http.Request, id string, params GetClassOccurrencesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the waitlist of a class occurrence
+// (GET /classes/{id}/occurrences/{date}/waitlist)
+func (_ Unimplemented) GetWaitlist(w http.ResponseWriter, r *http.Request, id string, date openapi_types.Date) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all members
+// (GET /members)
+func (_ Unimplemented) GetMembers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new member
+// (POST /members)
+func (_ Unimplemented) CreateMember(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a member
+// (DELETE /members/{id})
+func (_ Unimplemented) DeleteMember(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a member by ID
+// (GET /members/{id})
+func (_ Unimplemented) GetMember(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Partially update a member
+// (PATCH /members/{id})
+func (_ Unimplemented) PatchMember(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace a member
+// (PUT /members/{id})
+func (_ Unimplemented) UpdateMember(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetBookings operation middleware
+func (siw *ServerInterfaceWrapper) GetBookings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBookingsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "class_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "class_id", r.URL.Query(), &params.ClassId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "class_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "member" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "member", r.URL.Query(), &params.Member)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "member", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookings(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// BookClass operation middleware
+func (siw *ServerInterfaceWrapper) BookClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BookClass(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CancelBooking operation middleware
+func (siw *ServerInterfaceWrapper) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CancelBookingParams
+
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", r.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reason", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelBooking(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetClasses operation middleware
+func (siw *ServerInterfaceWrapper) GetClasses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClassesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "active_on" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active_on", r.URL.Query(), &params.ActiveOn)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "active_on", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_spots" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_spots", r.URL.Query(), &params.MinSpots)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_spots", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClasses(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateClass operation middleware
+func (siw *ServerInterfaceWrapper) CreateClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateClass(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// MemberName The name of the member
-	MemberName *string `json:"member_name,omitempty"`
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ClassRequest defines model for ClassRequest.
-type ClassRequest struct {
-	Capacity  *int                `json:"capacity,omitempty"`
-	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
-	Name      *string             `json:"name,omitempty"`
-	StartDate *openapi_types.Date `json:"start_date,omitempty"`
+// DeleteClass operation middleware
+func (siw *ServerInterfaceWrapper) DeleteClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteClassParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteClass(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// BookClassJSONRequestBody defines body for BookClass for application/json ContentType.
-type BookClassJSONRequestBody = BookingRequest
+// GetClass operation middleware
+func (siw *ServerInterfaceWrapper) GetClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// CreateClassJSONRequestBody defines body for CreateClass for application/json ContentType.
-type CreateClassJSONRequestBody = ClassRequest
+	var err error
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get all bookings
-	// (GET /bookings)
-	GetBookings(w http.ResponseWriter, r *http.Request)
-	// Book a class
-	// (POST /bookings)
-	BookClass(w http.ResponseWriter, r *http.Request)
-	// Get all classes
-	// (GET /classes)
-	GetClasses(w http.ResponseWriter, r *http.Request)
-	// Create a new class
-	// (POST /classes)
-	CreateClass(w http.ResponseWriter, r *http.Request)
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClass(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// PatchClass operation middleware
+func (siw *ServerInterfaceWrapper) PatchClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type Unimplemented struct{}
+	var err error
 
-// Get all bookings
-// (GET /bookings)
-func (_ Unimplemented) GetBookings(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchClassParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchClass(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// Book a class
-// (POST /bookings)
-func (_ Unimplemented) BookClass(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// UpdateClass operation middleware
+func (siw *ServerInterfaceWrapper) UpdateClass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateClassParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateClass(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// Get all classes
-// (GET /classes)
-func (_ Unimplemented) GetClasses(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// GetClassOccurrences operation middleware
+func (siw *ServerInterfaceWrapper) GetClassOccurrences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClassOccurrencesParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClassOccurrences(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// Create a new class
-// (POST /classes)
-func (_ Unimplemented) CreateClass(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// GetWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) GetWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "date" -------------
+	var date openapi_types.Date
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "date", runtime.ParamLocationPath, chi.URLParam(r, "date"), &date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWaitlist(w, r, id, date)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// GetMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMembers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// CreateMember operation middleware
+func (siw *ServerInterfaceWrapper) CreateMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// GetBookings operation middleware
-func (siw *ServerInterfaceWrapper) GetBookings(w http.ResponseWriter, r *http.Request) {
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateMember(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteMember operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMember(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBookings(w, r)
+		siw.Handler.DeleteMember(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -115,12 +1176,23 @@ func (siw *ServerInterfaceWrapper) GetBookings(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// BookClass operation middleware
-func (siw *ServerInterfaceWrapper) BookClass(w http.ResponseWriter, r *http.Request) {
+// GetMember operation middleware
+func (siw *ServerInterfaceWrapper) GetMember(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.BookClass(w, r)
+		siw.Handler.GetMember(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -130,12 +1202,23 @@ func (siw *ServerInterfaceWrapper) BookClass(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetClasses operation middleware
-func (siw *ServerInterfaceWrapper) GetClasses(w http.ResponseWriter, r *http.Request) {
+// PatchMember operation middleware
+func (siw *ServerInterfaceWrapper) PatchMember(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetClasses(w, r)
+		siw.Handler.PatchMember(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -145,12 +1228,23 @@ func (siw *ServerInterfaceWrapper) GetClasses(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateClass operation middleware
-func (siw *ServerInterfaceWrapper) CreateClass(w http.ResponseWriter, r *http.Request) {
+// UpdateMember operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMember(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateClass(w, r)
+		siw.Handler.UpdateMember(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -279,12 +1373,51 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/bookings", wrapper.BookClass)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/bookings/{id}", wrapper.CancelBooking)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/classes", wrapper.GetClasses)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/classes", wrapper.CreateClass)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/classes/{id}", wrapper.DeleteClass)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/classes/{id}", wrapper.GetClass)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/classes/{id}", wrapper.PatchClass)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/classes/{id}", wrapper.UpdateClass)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/classes/{id}/occurrences", wrapper.GetClassOccurrences)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/classes/{id}/occurrences/{date}/waitlist", wrapper.GetWaitlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/members", wrapper.GetMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/members", wrapper.CreateMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/members/{id}", wrapper.DeleteMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/members/{id}", wrapper.GetMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/members/{id}", wrapper.PatchMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/members/{id}", wrapper.UpdateMember)
+	})
 
 	return r
 }
@@ -292,18 +1425,70 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xWQW/bOgz+KwLfO7q1kxR5qG+vLVBk2GEYdhmGoWAsOlFrS5qkZA2K/PeBsp0mtdtm",
-	"xQbssJtCUR/Jj/wYP0Bhams06eAhfwBfLKnGeLww5k7pxUf6tiIf2EL3WNuK+FhU6P2NkpDD9D/CQp6X",
-	"Z0hycj4Z41SeT6c4gaT10lgT5PDZLFBcsgUSkBjYNs7Gk5NRdpKNIIGa6jm5zv2dWWpxZQi2CVhnLLmg",
-	"yB/GfgBJvnDKBmU05PBpSWJ2JUwpwpJE9BNzY+5IQgJhYxnXB6f0glH3sxtC4ptjsZp6hlC8pUKVqhDs",
-	"0sHNG24hgdK4GgPkDcIA8gErr6XZOPdhtjuLmd9SERg4tmKvu09IRouFChs+t0+VDrQgx29Jy5uu5Fcr",
-	"6FLvXfiALhyL0y+BTUqXJmKrwIMJ15Upzb34/8MMEliT8w1Po9PsNOOQxpJGqyCHSTQlYDEsY8Vp25T4",
-	"Y0GRE2YEmesZT/o1hYvOJwFH3hrtG7rGWdbvznvlA3emAxaOglO0Jin8qijI+3JVVZtYm1/VNbpNE0Vg",
-	"Ve1eRQEYP5APJ9MJyjWNvDAytqwwOpCOb9DaShXxVXrrObFO5nz611EJOfyTPu6BtF0C6ZMNEAnnOMqR",
-	"hDy4FW17NIz6NLQwezVzSWdDjM30GislhduF3GeGgQQ2WoxXaTzSix27bF2GG3Y0TypQ7V+WSV+a3e3B",
-	"Fnkc7T1B/fQuGhLavir7EKTlwQ7qYF7V75GbdrCuQ4kPbEe+f0taQxutNaBzuGmmdViP7dQ8K8c3DWcn",
-	"224kn1XtpSMM9Dt1e7DZj1ft0bH/SmBAArGr8s+QQn/040jskvwF495MsUCh6ftuI7MHuTU5yL88hXv8",
-	"ZxZr/tpbuQpySNGqdD2C7dftjwAAAP//T55AVIsKAAA=",
+	"H4sIAAAAAAAC/+xcW2/cuJL+K4R2H3YBud25bAbx0yZ2ZsaDyUmQZJCHQRCwpZKbiUQqJNWdPoH/+0EV",
+	"SV26qb6425kcnHmZ2BZFVhWrvrpqviWZqmolQVqTXHxLaq55BRY0/XbZaKM0/pSDybSorVAyuUjezYFJ",
+	"+Go/ZrSAqYLZObBaw0KoxrCa30DKVCWshZwVStPjQmhj6VmSJgL3+dKAXiVpInkFyUXidkvSxGRzqDie",
+	"a1c1PjFWC3mT3N6myc9KZ7BJ0iWXGZR0zkypz0LeGMaLAjKkYLaiB9mcyxtgQhoLPEeqNXyCzAp5w4Qd",
+	"Iaqg8/o05VDwprTJRcFLA2mgcaZUCVwSkb+LSti43Cr+VVRNxWRTzYBEJyxUhgnpZDgunpI2jVLyf9M0",
+	"8RvjL/ibkO63By2BQlq4AZ3c3t6GTeiWnzt54Y+1VjVoK4AeZCTTkiP1HzVwo6Q7tc/T+/mqL3W25Ib5",
+	"FyFP0vUbTJP24UduY9uB3LpfoXSFLyY5t3BmRQXRQ0puzEeRx+/g+iqoLK2js0aIpX3cBUStgFew715I",
+	"cHwXU0MmCpExXBK28wJYZzm2824+u802Xq4AFXEPWbmFKZPKMgOWKdmZWsVzYDMolA7rDIOvwti4KPyZ",
+	"+8nVLY5tYyzX1kT1iMSKj/sSgJwZMEYomTIlyxWxgfBEVweGLYWdM+5f9Lq1n8IZy21jovps56AHKi0M",
+	"45kVC2BKD3QbJFrsn4l7mvRsJfmQRrDQ/0XNEMWQCm/KrxFELr4lvCxfFcnFn9+S/9ZQJBfJf513cH/u",
+	"IeAcV78BUytpILlN11Eg55bAhmAqudi+lycg6YjjWvMVUavhSyM05Mghbfphg4MPHQ9v4EsDhm4WvvKq",
+	"Lomjzq6TJz8Bz/KnxWMO+aOnjx7yJ/nTJ0/4oyRYWvJw+vDR2YPp2fRBMlDzsVcfI9lrEPgdcOT6RioN",
+	"OdqTkHVj096O+Abqi+WfQbJCq6r3UEMBGmTmPFxL6smgJ2WKlvKSLRGWW3NDim7EAuQ+4HQgvrRW0vKZ",
+	"suVcsaoxls2AtbZxGKjEheyPHJGyfzoUc8fOkYDkkYhZRSynJwKiJRe2FMbGIpUhRb8pH3OEV/qRUcFF",
+	"Se43+GOncsKwoilLFKOdc8uGl94PgTaw6RI3iAUZNc+EXY34gTZKCl6Fjs248z09ypRkwLP5GkVtzJMm",
+	"eaNdJFMJ2Vgw8QNLkDd2jgfSbuGShGThtdjeIPOP4zZWcuMk1bfdRprTuHbaL/bqAVFL7HUNWaNJ73eB",
+	"/ptuZbCBLdJwacAdxeE2J92Pi1plvOyZzcZFcsN+/fXi5csk7dxKMv3pYjqNHYdb/FPJkcOun/3jGQtL",
+	"8KQedf3tXzSo8edXzawUMtnLkZOx/HVunI4/xonTBj0XPm7z+xnqdpPbqTbBFO5JyQ9U2wMV7yhFeumC",
+	"580r0MDtrhzMO0BKwdz6vd0QVFyUcbOhR+tJRSPFlwYYlzkzljx1qZagM24Grs9teyecHE8jxoGSfN3e",
+	"uUg0AXiFeYYP94MPa92Xd/ex0F9I/+OHA+75d+/490OMU6GFO/sYuHA7xEN+r0nJJzWXk1zB//tnk0xV",
+	"XXHkNzWX7ErBZgjfauJuJRqFif7l+pCqu6ljr+5V1segIfE+o9gRG3n1arPxUHHzTi8asmyPu8LTtWA1",
+	"vtMhOdJh2Uk/KelI2Am3IHOzHdn8ZgxXnrwWoFHBpC+pbbs3UytrmLGiLBlfcFHyWRkPXrckFhs8ubUn",
+	"5iqmuIOI4xSo06spj6jloN6My32tmc8MSOvSEh9w+0rqTj4Qf94MwoA1+QJ8RkFmc8ibktSRO3FO2DP3",
+	"A0lVNXg+UCjLYAF6xXK+YjOwSwDJhDVe6ujjQOak3GaSpBvQuzJj1rAygfclwOfN+LmF64BJlbPZBpI0",
+	"WbrSxLzBK9ciSRPDqZ7cyAhSrYM5BrQZEDkR8q6Qlx49uQJDhULdyJSZJpszbthclYLY61G605h3+JSV",
+	"ifiUNGm1LCpJkAsoVU2X6a5K+/UThj4U76dWQlrDuDRL0OzhdOptRzKoaouXazmjtJlyZKnsHBPmitts",
+	"DiZlj6ePmTC4MeiFb4NUwhhchP8pAZ+pRmcxLajAGH4zRr7WSqeeDrT4Jssgjq2d5woq8bZd/DMX8dJi",
+	"eO1S5RCLvmNg8N4XEV5Iq1dxRybkzaib8M9DnNnVHXoxaK1Vpey2AtsRTuiQgPiTEhLyQe1kb+ewn7Pz",
+	"NY0uMjhJqSAQy4Au6fhi3T3W9mtlhHsptseDsxk3kLO65NnaZqGT9qWBZtSZ7qzVh72Ce0bRoX6qoSoy",
+	"qxjvdVeCkfnlzqxp5V7BIP5JyEKR0QlLSd8vpSrUV/bs9XWSJgvQxhH7YDKdTJEbVYPktUgukkf0pzSp",
+	"uZ0Te+eeMPrlBiKajWBnAv0m7boR6MoMEzIrmxxy1sgSjGGFKC1oVwh1UpywZ2Xp/24Y19DVjNHPZaqa",
+	"oa1M2PO2K4trdB62ub7CSMU1Phm3jFNUktLbeBEBmDvMzrjWAgzjgx70ci5KqihpoCMqpbuIGPEV0Yjq",
+	"Ctc5ShVsoChJB23vkfClW3Luuru36c6Fvn9+m66LnZLCQJxTX2FabIr2xrvq/pbu+H7nhJy7rWaz66sJ",
+	"eyZXTJH+L3jZQHBkG3XyjBs4E9KARANdQLlypet9eoGTEe5aGLg7b9Lx5sExdoh/1B2xA1H3OFJpxgsL",
+	"eufZ2FM4/dleyLsOt+qUR1MUREd6HI0f2j7sDj6svfkhTYLtE349nE7xn0xJC9JVOeq6FBnZ9PknP5zQ",
+	"nbZHm5IKrAS6Q4afOTRSRQ8ZXbDlQz3pzQPx97Gja63dJBe8FLkHxpTR7Aa1ez0koBtqqorrlQOj9iTv",
+	"+4jBIWQh0ZceIrQrlTxX+erUQglVmNthrG11A7cbV/Jgk3W/TQhKi6ZElh5OH56M0GGoGbm/y0GvKniS",
+	"XiTJ89z57kEIt+syvdDTkTwnZJ8ujlsPI3CRV3w653Fkhom2VDq8ha8UqpG5e+Pp2Bue0VWYcXDlH48J",
+	"xH5gkZgnch0rkKdrlPJSA89XbI5SkmuVJVwrTLtGrbUPQ9mpbci1RKzpO6pISKLpURuonH8T+a3js4RY",
+	"oPxWFfbM4YYZDFUgmxpK4AaMS7ZrZdmMZ5/DPTuyHJG+dTlhl23A0wKcK9+whTBiVgITkv3y4h07H48l",
+	"3B7P2yBwLZrYYy4HSXRMBTTFGK4DU/L7Q1s8yFeuD2kJw2aAP/SnT2Io7qe+th32HXA6ZuPPQ8LYXmAH",
+	"OOVqpy0HSVxfjZpjOGG3GT7vxBpsoxPsUPWdrvRyBlL/0AfYHqa3SgzGuZ8wJuHD75RxbM3H4u9YjD1h",
+	"746Nrz010fD6su1t/CXRdVvwnCvjc05USS6kcbhk4atNI9HsiCXQP4cHqOt1V1+j7YMjgRAq2Qza+3Lr",
+	"aRIF8UqNBc4Onz8qeYIIL5CqGykJ7GPx7YS9R8qs8vXlEf5C1ZM4IAesUtS8ShnLHj154mqZvObaTu43",
+	"ZI5z9T0j5xERkRMiN2sZui3rkzMuV74xUEJh01DOaG96EF+gkM6sYkshc7XESSmBpVZiDSRrXcZI2iXk",
+	"RzpqwOXW0eF00yFr6/L5FP3abMVCiyx+plHaRrMCb2Bn/t9ehz1Nzga/ta2rNDlrf/7OGUQ3oLE1f/B3",
+	"f2z6gELbJ4lA/PdHjucRl1TpvM9MYjD9sX8esffZh0yQrXcy1wrAG33MI6emtk+ChbbP+janGAPzhfNx",
+	"/nYNZtHzu5AXr2PGMpVA5EGhmm4VaRBI0VYUpCz7mYRX/52JxBX9vd+zQjclrGnzmWF7jzV1pirKNNa6",
+	"7cI4V+hO8q0L+mwEHxlwGO6QOeMGoVmZ3vuI1m24GM1IjOWrA/IRx1ow8MOiL/d5zRh0xu40sH3QnTq5",
+	"bgm+3d67Q+937fWRK127GrxSdxMh8TWwrkhOWl06moYQPB7RJvftVMatR4PVAhb3L+tNv+I3ma38Pgek",
+	"uIOq9lFp7Qc8F93mBgd/1NRMd3boMiIoc8NqDTQX4GMojyRspvKVy34Mr1p1YbopwTBu2Os/3jG8xdWm",
+	"bb1GAo43rR/B5343vW3odu6K+qcACPfhn/se8G4o8ZprKzgW2Rw3fbyom0jK/gaoNxlGQUghByYxYW8g",
+	"b7L2U4cQp8ygVMvhh4w0coKnUrWuUovwUmSghBnlUov27YKqAo01Iqe0oErdXMIn93EkObfH06dtj2+r",
+	"54LeV5UjDmzYLnBfUygchVHSLVUSSdGQp6zmxg43wqSH8VJJ2DQ+Z+Z/W99/nPV5YxrWjPuR3nk3KjFe",
+	"RXvxteYy74d87VCXkFahYmYabDtJ1/a6QGjWDvW55HzCLntpvWrQR3bD5ORKqCewOQjmthsZBYvHHK96",
+	"zP1Fvncj7/+59xmHojmoNGT+Bv/iTb7fEeglGKJgJbegA20nr/38zrdSV6hG0wyd8QUuPJH9z2aFih4K",
+	"+7+nKRAdW5HYaxy705bI+NwGSLwNun5kdIlXTfJ2pag7Bps0fNcbZjW9ccvtVn/+DU+/Pe9//7ajlh6G",
+	"E8JsD7aGuOsbKbneyQL3xaN3w0K6+SJX+ZowmhDj/f5K15fo/t8HXTdwOD+UKVkIXXU+NQoF77tRsx8D",
+	"AvYYWouc6k1j/NwfwojWesy77Si8cCo7OiJbGzRlO/vp3wyZktf/nqVs6NxLv+Qew5felysRsb7r7HRQ",
+	"RO0aUVJJGCmFBgZ3lEJfhumj+4gMh5+23EMxdJ8vczbl6p4cWZDbEvS5j7x6DdHGuHk/Lt2QWRVIGy3q",
+	"9VYEVT2oqufeoTxfaDeBhtg8yDY+Q239R2c45Wnmatl7l0q+Y/W1ntbsKpR5Yd+pUtZO6I0CwsuNaZF4",
+	"fatqP9Pabu33b+xbFPKO6Hm0lFyVy29zlzJXO8L4w9W53oIlxXcTecwqFj5QY8aqum8sLgyuuJt7gOWW",
+	"AVoqhP1oyPkdFfVe0uqX+w5+3R1iI4WsDhh8JStWdfn7qv/trrqrmlQ9FulzJB3Bs+5DA7bA/2NOo8vk",
+	"IjnntThfPMDv5P41AO6KtD8tTgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
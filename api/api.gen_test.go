@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,7 +18,7 @@ func TestUnimplementedServer(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/bookings", nil)
 		rec := httptest.NewRecorder()
 
-		server.GetBookings(rec, req)
+		server.GetBookings(rec, req, GetBookingsParams{})
 
 		assert.Equal(t, http.StatusNotImplemented, rec.Code)
 	})
@@ -30,11 +32,128 @@ func TestUnimplementedServer(t *testing.T) {
 		assert.Equal(t, http.StatusNotImplemented, rec.Code)
 	})
 
+	t.Run("CancelBooking", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/bookings/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.CancelBooking(rec, req, "123", CancelBookingParams{})
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("GetClass", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/classes/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.GetClass(rec, req, "123")
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("UpdateClass", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", "/classes/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.UpdateClass(rec, req, "123", UpdateClassParams{})
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("PatchClass", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", "/classes/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.PatchClass(rec, req, "123", PatchClassParams{})
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("DeleteClass", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/classes/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.DeleteClass(rec, req, "123", DeleteClassParams{})
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("GetClassOccurrences", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/classes/123/occurrences", nil)
+		rec := httptest.NewRecorder()
+
+		server.GetClassOccurrences(rec, req, "123", GetClassOccurrencesParams{})
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("GetWaitlist", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/classes/123/occurrences/2025-03-01/waitlist", nil)
+		rec := httptest.NewRecorder()
+
+		server.GetWaitlist(rec, req, "123", openapi_types.Date{Time: time.Now()})
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("GetMembers", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/members", nil)
+		rec := httptest.NewRecorder()
+
+		server.GetMembers(rec, req)
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("CreateMember", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/members", nil)
+		rec := httptest.NewRecorder()
+
+		server.CreateMember(rec, req)
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("GetMember", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/members/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.GetMember(rec, req, "123")
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("UpdateMember", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", "/members/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.UpdateMember(rec, req, "123")
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("PatchMember", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", "/members/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.PatchMember(rec, req, "123")
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("DeleteMember", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/members/123", nil)
+		rec := httptest.NewRecorder()
+
+		server.DeleteMember(rec, req, "123")
+
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
 	t.Run("GetClasses", func(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/classes", nil)
 		rec := httptest.NewRecorder()
 
-		server.GetClasses(rec, req)
+		server.GetClasses(rec, req, GetClassesParams{})
 
 		assert.Equal(t, http.StatusNotImplemented, rec.Code)
 	})
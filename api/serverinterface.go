@@ -3,22 +3,23 @@ package api
 import (
 	"net/http"
 
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/sinhaseemant/glofox-backend/internal/handlers"
-	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
 )
 
 // NewServerInterface creates and returns a new instance of ServerInterface.
 // It initializes and returns a pointer to the serverInterface struct, which
 // implements the ServerInterface interface that is present in api.gen.go file.
 
-func NewServerInterface(repo *storage.MongoRepository, classHandler handlers.ClassHandlerInterface, bookingHandler handlers.BookingHandlerInterface) ServerInterface {
-	return &serverInterface{repo: repo, ch: classHandler, bh: bookingHandler}
+func NewServerInterface(classHandler handlers.ClassHandlerInterface, bookingHandler handlers.BookingHandlerInterface, memberHandler handlers.MemberHandlerInterface) ServerInterface {
+	return &serverInterface{ch: classHandler, bh: bookingHandler, mh: memberHandler}
 }
 
 type serverInterface struct {
-	repo *storage.MongoRepository
-	ch   handlers.ClassHandlerInterface
-	bh   handlers.BookingHandlerInterface
+	ch handlers.ClassHandlerInterface
+	bh handlers.BookingHandlerInterface
+	mh handlers.MemberHandlerInterface
 }
 
 func (s *serverInterface) BookClass(w http.ResponseWriter, r *http.Request) {
@@ -29,10 +30,112 @@ func (s *serverInterface) CreateClass(w http.ResponseWriter, r *http.Request) {
 	s.ch.CreateClassHandler(w, r)
 }
 
-func (s *serverInterface) GetClasses(w http.ResponseWriter, r *http.Request) {
-	s.ch.GetClassesHandler(w, r)
+func (s *serverInterface) GetClasses(w http.ResponseWriter, r *http.Request, params GetClassesParams) {
+	query := handlers.ClassQuery{
+		PageQuery: toPageQuery(params.Limit, params.Cursor),
+		ActiveOn:  toCustomDate(params.ActiveOn),
+		From:      toCustomDate(params.From),
+		To:        toCustomDate(params.To),
+		MinSpots:  params.MinSpots,
+	}
+	if params.Name != nil {
+		query.Name = *params.Name
+	}
+	if params.Sort != nil {
+		query.Sort = string(*params.Sort)
+	}
+	s.ch.GetClassesHandler(w, r, query)
 }
 
-func (s *serverInterface) GetBookings(w http.ResponseWriter, r *http.Request) {
-	s.bh.GetBookingsHandler(w, r)
+func (s *serverInterface) GetBookings(w http.ResponseWriter, r *http.Request, params GetBookingsParams) {
+	query := handlers.BookingQuery{
+		PageQuery: toPageQuery(params.Limit, params.Cursor),
+		Date:      toCustomDate(params.Date),
+		From:      toCustomDate(params.From),
+		To:        toCustomDate(params.To),
+	}
+	if params.ClassId != nil {
+		query.ClassID = *params.ClassId
+	}
+	if params.Member != nil {
+		query.Member = *params.Member
+	}
+	if params.Status != nil {
+		query.Status = string(*params.Status)
+	}
+	s.bh.GetBookingsHandler(w, r, query)
+}
+
+func (s *serverInterface) CancelBooking(w http.ResponseWriter, r *http.Request, id string, params CancelBookingParams) {
+	var reason string
+	if params.Reason != nil {
+		reason = *params.Reason
+	}
+	s.bh.CancelBookingHandler(w, r, id, reason)
+}
+
+func (s *serverInterface) GetWaitlist(w http.ResponseWriter, r *http.Request, id string, date openapi_types.Date) {
+	s.bh.GetWaitlistHandler(w, r, id, models.CustomDate(date.Time))
+}
+
+func (s *serverInterface) GetClass(w http.ResponseWriter, r *http.Request, id string) {
+	s.ch.GetClassHandler(w, r, id)
+}
+
+func (s *serverInterface) UpdateClass(w http.ResponseWriter, r *http.Request, id string, params UpdateClassParams) {
+	s.ch.UpdateClassHandler(w, r, id, params.Force != nil && *params.Force)
+}
+
+func (s *serverInterface) PatchClass(w http.ResponseWriter, r *http.Request, id string, params PatchClassParams) {
+	s.ch.PatchClassHandler(w, r, id, params.Force != nil && *params.Force)
+}
+
+func (s *serverInterface) DeleteClass(w http.ResponseWriter, r *http.Request, id string, params DeleteClassParams) {
+	s.ch.DeleteClassHandler(w, r, id, params.Force != nil && *params.Force)
+}
+
+func (s *serverInterface) GetClassOccurrences(w http.ResponseWriter, r *http.Request, id string, params GetClassOccurrencesParams) {
+	s.ch.GetClassOccurrencesHandler(w, r, id, toCustomDate(params.From), toCustomDate(params.To))
+}
+
+func (s *serverInterface) GetMembers(w http.ResponseWriter, r *http.Request) {
+	s.mh.GetMembersHandler(w, r)
+}
+
+func (s *serverInterface) CreateMember(w http.ResponseWriter, r *http.Request) {
+	s.mh.CreateMemberHandler(w, r)
+}
+
+func (s *serverInterface) GetMember(w http.ResponseWriter, r *http.Request, id string) {
+	s.mh.GetMemberHandler(w, r, id)
+}
+
+func (s *serverInterface) UpdateMember(w http.ResponseWriter, r *http.Request, id string) {
+	s.mh.UpdateMemberHandler(w, r, id)
+}
+
+func (s *serverInterface) PatchMember(w http.ResponseWriter, r *http.Request, id string) {
+	s.mh.PatchMemberHandler(w, r, id)
+}
+
+func (s *serverInterface) DeleteMember(w http.ResponseWriter, r *http.Request, id string) {
+	s.mh.DeleteMemberHandler(w, r, id)
+}
+
+// toCustomDate converts an optional OpenAPI date parameter to a models.CustomDate
+func toCustomDate(date *openapi_types.Date) *models.CustomDate {
+	if date == nil {
+		return nil
+	}
+	cd := models.CustomDate(date.Time)
+	return &cd
+}
+
+// toPageQuery converts the optional limit and cursor parameters of a list endpoint
+func toPageQuery(limit *Limit, cursor *Cursor) handlers.PageQuery {
+	query := handlers.PageQuery{Limit: limit}
+	if cursor != nil {
+		query.Cursor = *cursor
+	}
+	return query
 }
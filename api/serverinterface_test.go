@@ -4,8 +4,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
-	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/sinhaseemant/glofox-backend/internal/handlers"
+	"github.com/sinhaseemant/glofox-backend/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,8 +22,28 @@ func (m *MockClassHandler) CreateClassHandler(w http.ResponseWriter, r *http.Req
 	m.Called(w, r)
 }
 
-func (m *MockClassHandler) GetClassesHandler(w http.ResponseWriter, r *http.Request) {
-	m.Called(w, r)
+func (m *MockClassHandler) GetClassesHandler(w http.ResponseWriter, r *http.Request, query handlers.ClassQuery) {
+	m.Called(w, r, query)
+}
+
+func (m *MockClassHandler) GetClassHandler(w http.ResponseWriter, r *http.Request, id string) {
+	m.Called(w, r, id)
+}
+
+func (m *MockClassHandler) UpdateClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool) {
+	m.Called(w, r, id, force)
+}
+
+func (m *MockClassHandler) PatchClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool) {
+	m.Called(w, r, id, force)
+}
+
+func (m *MockClassHandler) DeleteClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool) {
+	m.Called(w, r, id, force)
+}
+
+func (m *MockClassHandler) GetClassOccurrencesHandler(w http.ResponseWriter, r *http.Request, id string, from, to *models.CustomDate) {
+	m.Called(w, r, id, from, to)
 }
 
 // MockBookingHandler is a mock implementation of BookingHandlerInterface.
@@ -32,25 +55,60 @@ func (m *MockBookingHandler) BookClassHandler(w http.ResponseWriter, r *http.Req
 	m.Called(w, r)
 }
 
-func (m *MockBookingHandler) GetBookingsHandler(w http.ResponseWriter, r *http.Request) {
+func (m *MockBookingHandler) GetBookingsHandler(w http.ResponseWriter, r *http.Request, query handlers.BookingQuery) {
+	m.Called(w, r, query)
+}
+
+func (m *MockBookingHandler) CancelBookingHandler(w http.ResponseWriter, r *http.Request, id string, reason string) {
+	m.Called(w, r, id, reason)
+}
+
+func (m *MockBookingHandler) GetWaitlistHandler(w http.ResponseWriter, r *http.Request, classID string, date models.CustomDate) {
+	m.Called(w, r, classID, date)
+}
+
+// MockMemberHandler is a mock implementation of MemberHandlerInterface.
+type MockMemberHandler struct {
+	mock.Mock
+}
+
+func (m *MockMemberHandler) CreateMemberHandler(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+}
+
+func (m *MockMemberHandler) GetMembersHandler(w http.ResponseWriter, r *http.Request) {
 	m.Called(w, r)
 }
 
+func (m *MockMemberHandler) GetMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	m.Called(w, r, id)
+}
+
+func (m *MockMemberHandler) UpdateMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	m.Called(w, r, id)
+}
+
+func (m *MockMemberHandler) PatchMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	m.Called(w, r, id)
+}
+
+func (m *MockMemberHandler) DeleteMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	m.Called(w, r, id)
+}
+
 func TestNewServerInterface(t *testing.T) {
-	mockRepo := &storage.MongoRepository{}
 	mockClassHandler := new(MockClassHandler)
 	mockBookingHandler := new(MockBookingHandler)
 
-	server := NewServerInterface(mockRepo, mockClassHandler, mockBookingHandler)
+	server := NewServerInterface(mockClassHandler, mockBookingHandler, new(MockMemberHandler))
 	assert.NotNil(t, server, "NewServerInterface should return a non-nil instance")
 }
 
 func TestServerInterfaceMethods(t *testing.T) {
-	mockRepo := &storage.MongoRepository{}
 	mockClassHandler := new(MockClassHandler)
 	mockBookingHandler := new(MockBookingHandler)
 
-	server := NewServerInterface(mockRepo, mockClassHandler, mockBookingHandler)
+	server := NewServerInterface(mockClassHandler, mockBookingHandler, new(MockMemberHandler))
 
 	tests := []struct {
 		name           string
@@ -74,34 +132,273 @@ func TestServerInterfaceMethods(t *testing.T) {
 			mockHandler:    &mockClassHandler.Mock,
 			expectedMethod: "CreateClassHandler",
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+
+			tt.mockHandler.On(tt.expectedMethod, rec, req).Return()
+
+			tt.method(server, rec, req)
+
+			tt.mockHandler.AssertCalled(t, tt.expectedMethod, rec, req)
+		})
+	}
+}
+
+func TestServerInterfaceGetClasses(t *testing.T) {
+	mockClassHandler := new(MockClassHandler)
+	server := NewServerInterface(mockClassHandler, new(MockBookingHandler), new(MockMemberHandler))
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	customDate := models.CustomDate(date)
+	limit, cursor, minSpots := 20, "djE6NjdlYWNkOWY0YWVkMzkzMmE2ZDk2NmEz", 2
+	name, sort := "yoga", GetClassesParamsSort("-start_date")
+
+	t.Run("GetClasses converts the query parameters", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classes", nil)
+		rec := httptest.NewRecorder()
+		expected := handlers.ClassQuery{
+			PageQuery: handlers.PageQuery{Limit: &limit, Cursor: cursor},
+			Name:      name,
+			ActiveOn:  &customDate,
+			MinSpots:  &minSpots,
+			Sort:      "-start_date",
+		}
+
+		mockClassHandler.On("GetClassesHandler", rec, req, expected).Return()
+
+		server.GetClasses(rec, req, GetClassesParams{
+			Limit:    &limit,
+			Cursor:   &cursor,
+			Name:     &name,
+			ActiveOn: &openapi_types.Date{Time: date},
+			MinSpots: &minSpots,
+			Sort:     &sort,
+		})
+
+		mockClassHandler.AssertCalled(t, "GetClassesHandler", rec, req, expected)
+	})
+
+	t.Run("GetClasses without parameters starts from the first page", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classes", nil)
+		rec := httptest.NewRecorder()
+
+		mockClassHandler.On("GetClassesHandler", rec, req, handlers.ClassQuery{}).Return()
+
+		server.GetClasses(rec, req, GetClassesParams{})
+
+		mockClassHandler.AssertCalled(t, "GetClassesHandler", rec, req, handlers.ClassQuery{})
+	})
+}
+
+func TestServerInterfaceGetBookings(t *testing.T) {
+	mockBookingHandler := new(MockBookingHandler)
+	server := NewServerInterface(new(MockClassHandler), mockBookingHandler, new(MockMemberHandler))
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	customDate := models.CustomDate(date)
+	classID := "67eacd9f4aed3932a6d966a3"
+	status := GetBookingsParamsStatus("active")
+	limit, cursor := 20, "djE6NjdlYWNkOWY0YWVkMzkzMmE2ZDk2NmEz"
+
+	t.Run("GetBookings converts the query parameters", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bookings", nil)
+		rec := httptest.NewRecorder()
+		expected := handlers.BookingQuery{
+			PageQuery: handlers.PageQuery{Limit: &limit, Cursor: cursor},
+			ClassID:   classID,
+			Date:      &customDate,
+			Status:    "active",
+		}
+
+		mockBookingHandler.On("GetBookingsHandler", rec, req, expected).Return()
+
+		server.GetBookings(rec, req, GetBookingsParams{ClassId: &classID, Date: &openapi_types.Date{Time: date}, Status: &status, Limit: &limit, Cursor: &cursor})
+
+		mockBookingHandler.AssertCalled(t, "GetBookingsHandler", rec, req, expected)
+	})
+
+	t.Run("GetBookings without parameters lists everything", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bookings", nil)
+		rec := httptest.NewRecorder()
+
+		mockBookingHandler.On("GetBookingsHandler", rec, req, handlers.BookingQuery{}).Return()
+
+		server.GetBookings(rec, req, GetBookingsParams{})
+
+		mockBookingHandler.AssertCalled(t, "GetBookingsHandler", rec, req, handlers.BookingQuery{})
+	})
+}
+
+func TestServerInterfaceCancelBooking(t *testing.T) {
+	mockBookingHandler := new(MockBookingHandler)
+	server := NewServerInterface(new(MockClassHandler), mockBookingHandler, new(MockMemberHandler))
+
+	t.Run("CancelBooking passes the ID and reason to CancelBookingHandler", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/bookings/abc", nil)
+		rec := httptest.NewRecorder()
+		reason := "Feeling unwell"
+
+		mockBookingHandler.On("CancelBookingHandler", rec, req, "abc", reason).Return()
+
+		server.CancelBooking(rec, req, "abc", CancelBookingParams{Reason: &reason})
+
+		mockBookingHandler.AssertCalled(t, "CancelBookingHandler", rec, req, "abc", reason)
+	})
+
+	t.Run("CancelBooking defaults to an empty reason", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/bookings/def", nil)
+		rec := httptest.NewRecorder()
+
+		mockBookingHandler.On("CancelBookingHandler", rec, req, "def", "").Return()
+
+		server.CancelBooking(rec, req, "def", CancelBookingParams{})
+
+		mockBookingHandler.AssertCalled(t, "CancelBookingHandler", rec, req, "def", "")
+	})
+}
+
+func TestServerInterfaceGetWaitlist(t *testing.T) {
+	mockBookingHandler := new(MockBookingHandler)
+	server := NewServerInterface(new(MockClassHandler), mockBookingHandler, new(MockMemberHandler))
+
+	req := httptest.NewRequest(http.MethodGet, "/classes/abc/occurrences/2025-03-01/waitlist", nil)
+	rec := httptest.NewRecorder()
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockBookingHandler.On("GetWaitlistHandler", rec, req, "abc", models.CustomDate(date)).Return()
+
+	server.GetWaitlist(rec, req, "abc", openapi_types.Date{Time: date})
+
+	mockBookingHandler.AssertCalled(t, "GetWaitlistHandler", rec, req, "abc", models.CustomDate(date))
+}
+
+func TestServerInterfaceClassByID(t *testing.T) {
+	mockClassHandler := new(MockClassHandler)
+	server := NewServerInterface(mockClassHandler, new(MockBookingHandler), new(MockMemberHandler))
+	force := true
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	fromDate := models.CustomDate(from)
+
+	tests := []struct {
+		name           string
+		method         func(w http.ResponseWriter, r *http.Request)
+		expectedMethod string
+		expectedArgs   []interface{}
+	}{
 		{
-			name: "GetClasses calls GetClassesHandler",
-			method: func(s ServerInterface, w http.ResponseWriter, r *http.Request) {
-				s.GetClasses(w, r)
+			name:           "GetClass calls GetClassHandler",
+			method:         func(w http.ResponseWriter, r *http.Request) { server.GetClass(w, r, "abc") },
+			expectedMethod: "GetClassHandler",
+			expectedArgs:   []interface{}{"abc"},
+		},
+		{
+			name: "UpdateClass passes force to UpdateClassHandler",
+			method: func(w http.ResponseWriter, r *http.Request) {
+				server.UpdateClass(w, r, "abc", UpdateClassParams{Force: &force})
 			},
-			mockHandler:    &mockClassHandler.Mock,
-			expectedMethod: "GetClassesHandler",
+			expectedMethod: "UpdateClassHandler",
+			expectedArgs:   []interface{}{"abc", true},
 		},
 		{
-			name: "GetBookings calls GetBookingsHandler",
-			method: func(s ServerInterface, w http.ResponseWriter, r *http.Request) {
-				s.GetBookings(w, r)
+			name: "PatchClass defaults force to false",
+			method: func(w http.ResponseWriter, r *http.Request) {
+				server.PatchClass(w, r, "abc", PatchClassParams{})
 			},
-			mockHandler:    &mockBookingHandler.Mock,
-			expectedMethod: "GetBookingsHandler",
+			expectedMethod: "PatchClassHandler",
+			expectedArgs:   []interface{}{"abc", false},
+		},
+		{
+			name: "GetClassOccurrences converts the date window",
+			method: func(w http.ResponseWriter, r *http.Request) {
+				server.GetClassOccurrences(w, r, "abc", GetClassOccurrencesParams{From: &openapi_types.Date{Time: from}})
+			},
+			expectedMethod: "GetClassOccurrencesHandler",
+			expectedArgs:   []interface{}{"abc", &fromDate, (*models.CustomDate)(nil)},
+		},
+		{
+			name: "DeleteClass passes force to DeleteClassHandler",
+			method: func(w http.ResponseWriter, r *http.Request) {
+				server.DeleteClass(w, r, "abc", DeleteClassParams{Force: &force})
+			},
+			expectedMethod: "DeleteClassHandler",
+			expectedArgs:   []interface{}{"abc", true},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req := httptest.NewRequest(http.MethodGet, "/classes/abc", nil)
 			rec := httptest.NewRecorder()
+			args := append([]interface{}{rec, req}, tt.expectedArgs...)
 
-			tt.mockHandler.On(tt.expectedMethod, rec, req).Return()
+			mockClassHandler.On(tt.expectedMethod, args...).Return()
 
-			tt.method(server, rec, req)
+			tt.method(rec, req)
 
-			tt.mockHandler.AssertCalled(t, tt.expectedMethod, rec, req)
+			mockClassHandler.AssertCalled(t, tt.expectedMethod, args...)
+		})
+	}
+}
+
+func TestServerInterfaceMembers(t *testing.T) {
+	mockMemberHandler := new(MockMemberHandler)
+	server := NewServerInterface(new(MockClassHandler), new(MockBookingHandler), mockMemberHandler)
+
+	tests := []struct {
+		name           string
+		method         func(w http.ResponseWriter, r *http.Request)
+		expectedMethod string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "GetMembers calls GetMembersHandler",
+			method:         func(w http.ResponseWriter, r *http.Request) { server.GetMembers(w, r) },
+			expectedMethod: "GetMembersHandler",
+		},
+		{
+			name:           "CreateMember calls CreateMemberHandler",
+			method:         func(w http.ResponseWriter, r *http.Request) { server.CreateMember(w, r) },
+			expectedMethod: "CreateMemberHandler",
+		},
+		{
+			name:           "GetMember calls GetMemberHandler",
+			method:         func(w http.ResponseWriter, r *http.Request) { server.GetMember(w, r, "abc") },
+			expectedMethod: "GetMemberHandler",
+			expectedArgs:   []interface{}{"abc"},
+		},
+		{
+			name:           "UpdateMember calls UpdateMemberHandler",
+			method:         func(w http.ResponseWriter, r *http.Request) { server.UpdateMember(w, r, "abc") },
+			expectedMethod: "UpdateMemberHandler",
+			expectedArgs:   []interface{}{"abc"},
+		},
+		{
+			name:           "PatchMember calls PatchMemberHandler",
+			method:         func(w http.ResponseWriter, r *http.Request) { server.PatchMember(w, r, "abc") },
+			expectedMethod: "PatchMemberHandler",
+			expectedArgs:   []interface{}{"abc"},
+		},
+		{
+			name:           "DeleteMember calls DeleteMemberHandler",
+			method:         func(w http.ResponseWriter, r *http.Request) { server.DeleteMember(w, r, "abc") },
+			expectedMethod: "DeleteMemberHandler",
+			expectedArgs:   []interface{}{"abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/members", nil)
+			rec := httptest.NewRecorder()
+			args := append([]interface{}{rec, req}, tt.expectedArgs...)
+
+			mockMemberHandler.On(tt.expectedMethod, args...).Return()
+
+			tt.method(rec, req)
+
+			mockMemberHandler.AssertCalled(t, tt.expectedMethod, args...)
 		})
 	}
 }
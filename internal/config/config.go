@@ -0,0 +1,257 @@
+// Package config loads the configuration of the application. Every setting has a default,
+// a YAML file named by CONFIG_FILE may override it, and an environment variable, which
+// .env may set, overrides both.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/internal/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the application
+type Config struct {
+	Server   Server              `yaml:"server"`
+	Storage  string              `yaml:"storage"` // Backend holding the data: mongo, memory or postgres
+	Mongo    storage.MongoConfig `yaml:"mongo"`
+	Postgres Postgres            `yaml:"postgres"`
+	CORS     CORS                `yaml:"cors"`
+	LogLevel string              `yaml:"log_level"` // trace, debug, info, warn, error, fatal, panic or disabled
+	Tracing  tracing.Config      `yaml:"tracing"`
+}
+
+// Server configures the HTTP server
+type Server struct {
+	Addr              string        `yaml:"addr"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	// ShutdownDelay is how long the server keeps serving while reporting itself not ready once
+	// asked to stop, so load balancers stop sending it requests before it closes its listener
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// ShutdownTimeout is how long requests in flight then get to finish
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// Postgres configures the postgres storage backend
+type Postgres struct {
+	URL string `yaml:"url"`
+}
+
+// CORS configures the cross-origin requests the API accepts
+type CORS struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() Config {
+	return Config{
+		Server: Server{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			// Together within the 30s Kubernetes waits between SIGTERM and SIGKILL
+			ShutdownDelay:   5 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Storage:  storage.BackendMongo,
+		Mongo:    storage.MongoConfig{Collections: storage.DefaultCollections},
+		CORS:     CORS{AllowedOrigins: []string{"*"}},
+		LogLevel: zerolog.LevelInfoValue,
+		Tracing:  tracing.Config{Exporter: tracing.ExporterNone, ServiceName: "glofox-backend", SampleRatio: 1},
+	}
+}
+
+// Load reads .env into the environment, when there is one, and returns the validated configuration
+func Load() (Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return Config{}, fmt.Errorf("failed to read .env: %w", err)
+	}
+	return load(os.Getenv)
+}
+
+// load returns the validated configuration read from the file named by CONFIG_FILE and getenv
+func load(getenv func(string) string) (Config, error) {
+	cfg := Default()
+	if path := getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+	problems := cfg.readEnv(getenv)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return Config{}, fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return cfg, nil
+}
+
+// readFile overrides the settings set in a YAML file. Unknown settings are an error, so a
+// misspelt one is not silently ignored.
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// readEnv overrides the settings set in the environment and returns the values it could not parse
+func (c *Config) readEnv(getenv func(string) string) []string {
+	var problems []string
+	str := func(env string, setting *string) {
+		if value := getenv(env); value != "" {
+			*setting = value
+		}
+	}
+	duration := func(env string, setting *time.Duration) {
+		value := getenv(env)
+		if value == "" {
+			return
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s=%q is not a duration such as 30s or 1m", env, value))
+			return
+		}
+		*setting = d
+	}
+
+	// PORT is what docker-compose and most platforms set, LISTEN_ADDR also picks the interface
+	if port := getenv("PORT"); port != "" {
+		c.Server.Addr = ":" + port
+	}
+	str("LISTEN_ADDR", &c.Server.Addr)
+	duration("READ_HEADER_TIMEOUT", &c.Server.ReadHeaderTimeout)
+	duration("READ_TIMEOUT", &c.Server.ReadTimeout)
+	duration("WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	duration("IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	duration("SHUTDOWN_DELAY", &c.Server.ShutdownDelay)
+	duration("SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+
+	str("STORAGE_BACKEND", &c.Storage)
+	str("MONGO_URI", &c.Mongo.URI)
+	str("MONGO_DATABASE", &c.Mongo.Database)
+	str("MONGO_CLASSES_COLLECTION", &c.Mongo.Collections.Classes)
+	str("MONGO_BOOKINGS_COLLECTION", &c.Mongo.Collections.Bookings)
+	str("MONGO_OCCURRENCES_COLLECTION", &c.Mongo.Collections.Occurrences)
+	str("MONGO_WAITLIST_COLLECTION", &c.Mongo.Collections.Waitlist)
+	str("MONGO_MEMBERS_COLLECTION", &c.Mongo.Collections.Members)
+	str("MONGO_MIGRATIONS_COLLECTION", &c.Mongo.Collections.Migrations)
+	str("POSTGRES_URL", &c.Postgres.URL)
+
+	if origins := getenv("CORS_ALLOWED_ORIGINS"); origins != "" {
+		c.CORS.AllowedOrigins = nil
+		for _, origin := range strings.Split(origins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				c.CORS.AllowedOrigins = append(c.CORS.AllowedOrigins, origin)
+			}
+		}
+	}
+	str("LOG_LEVEL", &c.LogLevel)
+
+	str("TRACING_EXPORTER", &c.Tracing.Exporter)
+	str("OTEL_EXPORTER_OTLP_ENDPOINT", &c.Tracing.OTLPEndpoint)
+	str("OTEL_SERVICE_NAME", &c.Tracing.ServiceName)
+	if value := getenv("TRACING_SAMPLE_RATIO"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("TRACING_SAMPLE_RATIO=%q is not a number", value))
+		} else {
+			c.Tracing.SampleRatio = ratio
+		}
+	}
+	return problems
+}
+
+// validate returns what is wrong with the configuration, naming the environment variable that sets it
+func (c *Config) validate() []string {
+	var problems []string
+	if c.Server.Addr == "" {
+		problems = append(problems, "LISTEN_ADDR is empty")
+	}
+	timeouts := []struct {
+		env     string
+		timeout time.Duration
+	}{
+		{"READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout},
+		{"READ_TIMEOUT", c.Server.ReadTimeout},
+		{"WRITE_TIMEOUT", c.Server.WriteTimeout},
+		{"IDLE_TIMEOUT", c.Server.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout},
+	}
+	for _, t := range timeouts {
+		if t.timeout <= 0 {
+			problems = append(problems, fmt.Sprintf("%s must be positive, got %s", t.env, t.timeout))
+		}
+	}
+	if c.Server.ShutdownDelay < 0 {
+		problems = append(problems, fmt.Sprintf("SHUTDOWN_DELAY must not be negative, got %s", c.Server.ShutdownDelay))
+	}
+
+	switch c.Storage {
+	case storage.BackendMongo:
+		if c.Mongo.URI == "" {
+			problems = append(problems, "MONGO_URI is required by the mongo storage backend")
+		}
+		if err := c.Mongo.Collections.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("MONGO_*_COLLECTION: %v", err))
+		}
+	case storage.BackendMemory:
+	case storage.BackendPostgres:
+		if c.Postgres.URL == "" {
+			problems = append(problems, "POSTGRES_URL is required by the postgres storage backend")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("STORAGE_BACKEND=%q is unknown, expected %s, %s or %s",
+			c.Storage, storage.BackendMongo, storage.BackendMemory, storage.BackendPostgres))
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		problems = append(problems, "CORS_ALLOWED_ORIGINS is empty, use * to allow every origin")
+	}
+	if _, err := zerolog.ParseLevel(c.LogLevel); err != nil {
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL=%q is unknown, expected debug, info, warn or error", c.LogLevel))
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		problems = append(problems, fmt.Sprintf("TRACING_EXPORTER=%q is unknown, expected %s, %s or %s",
+			c.Tracing.Exporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("TRACING_SAMPLE_RATIO must be between 0 and 1, got %g", c.Tracing.SampleRatio))
+	}
+	if c.Tracing.ServiceName == "" {
+		problems = append(problems, "OTEL_SERVICE_NAME is empty")
+	}
+	return problems
+}
+
+// Level returns the zerolog level of LogLevel
+func (c Config) Level() zerolog.Level {
+	level, _ := zerolog.ParseLevel(c.LogLevel)
+	return level
+}
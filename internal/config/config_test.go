@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// env returns a getenv reading vars
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := load(env(map[string]string{"MONGO_URI": "mongodb://localhost:27017"}))
+	require.NoError(t, err)
+
+	want := Default()
+	want.Mongo.URI = "mongodb://localhost:27017"
+	assert.Equal(t, want, cfg)
+	assert.Equal(t, zerolog.InfoLevel, cfg.Level())
+}
+
+func TestLoadEnv(t *testing.T) {
+	cfg, err := load(env(map[string]string{
+		"PORT":                      "9090",
+		"WRITE_TIMEOUT":             "1m",
+		"SHUTDOWN_DELAY":            "0s",
+		"SHUTDOWN_TIMEOUT":          "45s",
+		"STORAGE_BACKEND":           "postgres",
+		"POSTGRES_URL":              "postgres://localhost/glofox",
+		"MONGO_BOOKINGS_COLLECTION": "class_bookings",
+		"CORS_ALLOWED_ORIGINS":      "https://app.glofox.com, https://admin.glofox.com",
+		"LOG_LEVEL":                 "debug",
+		"TRACING_EXPORTER":          "otlp",
+		"TRACING_SAMPLE_RATIO":      "0.25",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, ":9090", cfg.Server.Addr)
+	assert.Equal(t, time.Minute, cfg.Server.WriteTimeout)
+	assert.Equal(t, time.Duration(0), cfg.Server.ShutdownDelay)
+	assert.Equal(t, 45*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, Default().Server.ReadTimeout, cfg.Server.ReadTimeout)
+	assert.Equal(t, storage.BackendPostgres, cfg.Storage)
+	assert.Equal(t, "postgres://localhost/glofox", cfg.Postgres.URL)
+	assert.Equal(t, "class_bookings", cfg.Mongo.Collections.Bookings)
+	assert.Equal(t, storage.DefaultCollections.Classes, cfg.Mongo.Collections.Classes)
+	assert.Equal(t, []string{"https://app.glofox.com", "https://admin.glofox.com"}, cfg.CORS.AllowedOrigins)
+	assert.Equal(t, zerolog.DebugLevel, cfg.Level())
+	assert.Equal(t, tracing.ExporterOTLP, cfg.Tracing.Exporter)
+	assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+	assert.Equal(t, "glofox-backend", cfg.Tracing.ServiceName)
+
+	// LISTEN_ADDR is more specific than PORT
+	cfg, err = load(env(map[string]string{"STORAGE_BACKEND": "memory", "PORT": "9090", "LISTEN_ADDR": "127.0.0.1:8081"}))
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8081", cfg.Server.Addr)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+server:
+  addr: ":7070"
+  idle_timeout: 5m
+mongo:
+  uri: mongodb://mongo:27017
+  database: glofox_prod
+  collections:
+    members: gym_members
+cors:
+  allowed_origins: [https://app.glofox.com]
+log_level: warn
+`), 0o600))
+
+	cfg, err := load(env(map[string]string{"CONFIG_FILE": path, "LOG_LEVEL": "error"}))
+	require.NoError(t, err)
+	assert.Equal(t, ":7070", cfg.Server.Addr)
+	assert.Equal(t, 5*time.Minute, cfg.Server.IdleTimeout)
+	assert.Equal(t, Default().Server.WriteTimeout, cfg.Server.WriteTimeout)
+	assert.Equal(t, "mongodb://mongo:27017", cfg.Mongo.URI)
+	assert.Equal(t, "glofox_prod", cfg.Mongo.Database)
+	assert.Equal(t, "gym_members", cfg.Mongo.Collections.Members)
+	assert.Equal(t, storage.DefaultCollections.Bookings, cfg.Mongo.Collections.Bookings)
+	assert.Equal(t, []string{"https://app.glofox.com"}, cfg.CORS.AllowedOrigins)
+	// The environment overrides the file
+	assert.Equal(t, zerolog.ErrorLevel, cfg.Level())
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	_, err := load(env(map[string]string{"CONFIG_FILE": filepath.Join(t.TempDir(), "missing.yaml")}))
+	assert.ErrorContains(t, err, "failed to read the config file")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  adress: \":7070\"\n"), 0o600))
+	_, err = load(env(map[string]string{"CONFIG_FILE": path}))
+	assert.ErrorContains(t, err, "field adress not found")
+}
+
+func TestLoadInvalid(t *testing.T) {
+	_, err := load(env(map[string]string{
+		"READ_TIMEOUT":              "soon",
+		"IDLE_TIMEOUT":              "-1s",
+		"SHUTDOWN_DELAY":            "-1s",
+		"MONGO_WAITLIST_COLLECTION": "bookings",
+		"CORS_ALLOWED_ORIGINS":      " , ",
+		"LOG_LEVEL":                 "verbose",
+		"TRACING_EXPORTER":          "jaeger",
+		"TRACING_SAMPLE_RATIO":      "2",
+	}))
+	// Every problem is reported at once
+	assert.EqualError(t, err, `invalid configuration:
+  - READ_TIMEOUT="soon" is not a duration such as 30s or 1m
+  - IDLE_TIMEOUT must be positive, got -1s
+  - SHUTDOWN_DELAY must not be negative, got -1s
+  - MONGO_URI is required by the mongo storage backend
+  - MONGO_*_COLLECTION: the bookings and waitlist collections are both named "bookings"
+  - CORS_ALLOWED_ORIGINS is empty, use * to allow every origin
+  - LOG_LEVEL="verbose" is unknown, expected debug, info, warn or error
+  - TRACING_EXPORTER="jaeger" is unknown, expected none, stdout or otlp
+  - TRACING_SAMPLE_RATIO must be between 0 and 1, got 2`)
+
+	_, err = load(env(map[string]string{"STORAGE_BACKEND": "postgres"}))
+	assert.EqualError(t, err, "invalid configuration:\n  - POSTGRES_URL is required by the postgres storage backend")
+
+	_, err = load(env(map[string]string{"STORAGE_BACKEND": "redis"}))
+	assert.EqualError(t, err, "invalid configuration:\n  - STORAGE_BACKEND=\"redis\" is unknown, expected mongo, memory or postgres")
+}
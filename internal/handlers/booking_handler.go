@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/service"
 	"github.com/sinhaseemant/glofox-backend/internal/storage"
 	"github.com/sinhaseemant/glofox-backend/models"
 	"github.com/sinhaseemant/glofox-backend/util"
@@ -15,21 +16,68 @@ import (
 // BookingHandlerInterface defines the contract for BookingHandler
 type BookingHandlerInterface interface {
 	BookClassHandler(w http.ResponseWriter, r *http.Request)
-	GetBookingsHandler(w http.ResponseWriter, r *http.Request)
+	GetBookingsHandler(w http.ResponseWriter, r *http.Request, query BookingQuery)
+	CancelBookingHandler(w http.ResponseWriter, r *http.Request, id string, reason string)
+	GetWaitlistHandler(w http.ResponseWriter, r *http.Request, classID string, date models.CustomDate)
 }
 
 type BookingHandler struct {
-	Repo storage.BookingRepositoryInterface
+	Service *service.BookingService
 }
 
 // NewClassHandler initializes a handler with DI
-func NewBookingHandler(repo storage.BookingRepositoryInterface) BookingHandlerInterface {
-	return &BookingHandler{Repo: repo}
+func NewBookingHandler(svc *service.BookingService) BookingHandlerInterface {
+	return &BookingHandler{Service: svc}
+}
+
+// bookingRequest is the POST /bookings body: a booking plus the waitlist opt-in
+type bookingRequest struct {
+	models.Booking
+	Waitlist bool `json:"waitlist"`
+}
+
+// BookingQuery holds the GET /bookings query parameters, all optional
+type BookingQuery struct {
+	PageQuery
+	ClassID string
+	Member  string // Member ID, or member name for bookings made before members existed
+	Date    *models.CustomDate
+	From    *models.CustomDate
+	To      *models.CustomDate
+	Status  string
+}
+
+// filter validates the query and translates it to a storage filter
+func (q BookingQuery) filter() (storage.BookingFilter, []string) {
+	var validationErrors []string
+	filter := storage.BookingFilter{Date: q.Date, From: q.From, To: q.To, Status: q.Status}
+
+	if q.ClassID != "" {
+		classID, err := primitive.ObjectIDFromHex(q.ClassID)
+		if err != nil {
+			validationErrors = append(validationErrors, "Invalid class ID")
+		}
+		filter.ClassID = classID
+	}
+	if memberID, err := primitive.ObjectIDFromHex(q.Member); err == nil {
+		filter.MemberID = memberID
+	} else {
+		filter.MemberName = q.Member
+	}
+	if q.From != nil && q.To != nil && q.From.String() > q.To.String() {
+		validationErrors = append(validationErrors, "from must not be after to")
+	}
+	switch q.Status {
+	case "", models.BookingStatusActive, models.BookingStatusCancelled:
+	default:
+		validationErrors = append(validationErrors, "Status must be active or cancelled")
+	}
+	return filter, validationErrors
 }
 
 // BookClassHandler handles class bookings
 func (h *BookingHandler) BookClassHandler(w http.ResponseWriter, r *http.Request) {
-	var req models.Booking
+	var req bookingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
 		http.Error(w, string(resStr), http.StatusBadRequest)
@@ -42,15 +90,13 @@ func (h *BookingHandler) BookClassHandler(w http.ResponseWriter, r *http.Request
 		validationErrors = append(validationErrors, "Class ID is required")
 
 	}
-	if req.MemberName == "" {
-		validationErrors = append(validationErrors, "Member name is required")
+	if req.MemberID == primitive.NilObjectID {
+		validationErrors = append(validationErrors, "Member ID is required")
 	}
 
-	if req.Date.IsZero() {
-		validationErrors = append(validationErrors, "Booking date is required")
-	}
-	if req.ClassName == "" {
-		validationErrors = append(validationErrors, "Class name is required")
+	// The date can be derived from the session start, so either one is enough
+	if req.Date.IsZero() && req.StartsAt == nil {
+		validationErrors = append(validationErrors, "Booking date or session start is required")
 	}
 	if len(validationErrors) > 0 {
 		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErrors, http.StatusBadRequest, errors.New("Validation failed")))
@@ -59,42 +105,144 @@ func (h *BookingHandler) BookClassHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	ctx := r.Context()
-	// Insert booking into MongoDB
-	id, err := h.Repo.Create(ctx, &req)
+	// Validate against the class and reserve a spot, or join the waitlist if full
+	result, err := h.Service.Book(ctx, &req.Booking, req.Waitlist)
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErr.Errors, http.StatusBadRequest, validationErr))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, service.ErrMemberNotFound) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, err))
+		http.Error(w, string(resStr), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, errors.New("Class not found")))
+		http.Error(w, string(resStr), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, storage.ErrClassFull) || errors.Is(err, storage.ErrAlreadyBooked) || errors.Is(err, storage.ErrAlreadyWaitlisted) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusConflict, err))
+		http.Error(w, string(resStr), http.StatusConflict)
+		return
+	}
 	if err != nil {
 		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
 		http.Error(w, string(resStr), http.StatusInternalServerError)
 		return
 	}
-	req.ID = id
 
-	log.Info().Msgf("Booking created: %v", req)
-	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, req, http.StatusCreated, nil))
+	if result.Waitlist != nil {
+		log.Info().Ctx(r.Context()).Msgf("Member waitlisted: %v", result.Waitlist)
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, result.Waitlist, http.StatusAccepted, nil))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write(resStr)
+		return
+	}
+
+	log.Info().Ctx(r.Context()).Msgf("Booking created: %v", result.Booking)
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, result.Booking, http.StatusCreated, nil))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	w.Write(resStr)
 }
 
-// GetBookingsHandler retrieves all bookings
-func (h *BookingHandler) GetBookingsHandler(w http.ResponseWriter, r *http.Request) {
+// GetBookingsHandler retrieves the bookings matching the query parameters
+func (h *BookingHandler) GetBookingsHandler(w http.ResponseWriter, r *http.Request, query BookingQuery) {
+	filter, validationErrors := query.filter()
+	page, pageErrors := query.page(storage.Sort{})
+	validationErrors = append(validationErrors, pageErrors...)
+	if len(validationErrors) > 0 {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErrors, http.StatusBadRequest, errors.New("Validation failed")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
 
 	ctx := r.Context()
-	bookings, err := h.Repo.GetAll(ctx)
+	bookings, next, err := h.Service.GetAll(ctx, filter, page)
 	if err != nil {
 		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
 		http.Error(w, string(resStr), http.StatusInternalServerError)
 		return
 	}
 
-	if len(bookings) == 0 {
-		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, errors.New("No bookings found")))
+	if bookings == nil {
+		bookings = []models.Booking{}
+	}
+
+	response := util.SendGlobalResponse(util.StatusSuccess, bookings, http.StatusOK, nil)
+	response.NextCursor = next
+	resStr, _ := json.Marshal(response)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// CancelBookingHandler soft-cancels a booking, releasing its spot on the class
+func (h *BookingHandler) CancelBookingHandler(w http.ResponseWriter, r *http.Request, id string, reason string) {
+	bookingID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, errors.New("Invalid booking ID")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	// Cancel the booking and promote the first waitlisted member, if any
+	booking, err := h.Service.Cancel(ctx, bookingID, reason)
+	if errors.Is(err, storage.ErrNotFound) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, errors.New("Booking not found")))
 		http.Error(w, string(resStr), http.StatusNotFound)
 		return
 	}
+	if errors.Is(err, storage.ErrAlreadyCancelled) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusConflict, err))
+		http.Error(w, string(resStr), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
+		http.Error(w, string(resStr), http.StatusInternalServerError)
+		return
+	}
 
-	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, bookings, http.StatusOK, nil))
+	log.Info().Ctx(r.Context()).Msgf("Booking cancelled: %v", booking)
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, booking, http.StatusOK, nil))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	w.Write(resStr)
+}
 
+// GetWaitlistHandler lists the members queued for a class occurrence in order
+func (h *BookingHandler) GetWaitlistHandler(w http.ResponseWriter, r *http.Request, classID string, date models.CustomDate) {
+	id, err := primitive.ObjectIDFromHex(classID)
+	if err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, errors.New("Invalid class ID")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	entries, err := h.Service.GetWaitlist(ctx, id, date)
+	if errors.Is(err, storage.ErrNotFound) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, errors.New("Class not found")))
+		http.Error(w, string(resStr), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
+		http.Error(w, string(resStr), http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []models.WaitlistEntry{}
+	}
+
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, entries, http.StatusOK, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
 }
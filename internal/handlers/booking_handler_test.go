@@ -9,7 +9,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sinhaseemant/glofox-backend/internal/service"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
 	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/sinhaseemant/glofox-backend/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,36 +23,115 @@ type MockBookingRepository struct {
 	mock.Mock
 }
 
-func (m *MockBookingRepository) Create(ctx context.Context, booking *models.Booking) (primitive.ObjectID, error) {
-	args := m.Called(ctx, booking)
+func (m *MockBookingRepository) Create(ctx context.Context, booking *models.Booking, capacity int) (primitive.ObjectID, error) {
+	args := m.Called(ctx, booking, capacity)
 	return args.Get(0).(primitive.ObjectID), args.Error(1)
 }
 
-func (m *MockBookingRepository) GetAll(ctx context.Context) ([]models.Booking, error) {
-	args := m.Called(ctx)
+func (m *MockBookingRepository) GetAll(ctx context.Context, filter storage.BookingFilter, page storage.Page) ([]models.Booking, error) {
+	args := m.Called(ctx, filter, page)
 	return args.Get(0).([]models.Booking), args.Error(1)
 }
 
+func (m *MockBookingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Booking, error) {
+	args := m.Called(ctx, id)
+	booking, _ := args.Get(0).(*models.Booking)
+	return booking, args.Error(1)
+}
+
+func (m *MockBookingRepository) Cancel(ctx context.Context, id primitive.ObjectID, reason string) (*models.Booking, error) {
+	args := m.Called(ctx, id, reason)
+	booking, _ := args.Get(0).(*models.Booking)
+	return booking, args.Error(1)
+}
+
+func (m *MockBookingRepository) CountBooked(ctx context.Context, classID primitive.ObjectID, from, to models.CustomDate) (map[string]int, error) {
+	args := m.Called(ctx, classID, from, to)
+	booked, _ := args.Get(0).(map[string]int)
+	return booked, args.Error(1)
+}
+
+func (m *MockBookingRepository) GetActiveByClass(ctx context.Context, classID primitive.ObjectID, from models.CustomDate) ([]models.Booking, error) {
+	args := m.Called(ctx, classID, from)
+	bookings, _ := args.Get(0).([]models.Booking)
+	return bookings, args.Error(1)
+}
+
+// MockWaitlistRepository is a mock implementation of the WaitlistRepositoryInterface.
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) Add(ctx context.Context, entry *models.WaitlistEntry) (primitive.ObjectID, int, error) {
+	args := m.Called(ctx, entry)
+	return args.Get(0).(primitive.ObjectID), args.Int(1), args.Error(2)
+}
+
+func (m *MockWaitlistRepository) List(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) ([]models.WaitlistEntry, error) {
+	args := m.Called(ctx, classID, date)
+	entries, _ := args.Get(0).([]models.WaitlistEntry)
+	return entries, args.Error(1)
+}
+
+func (m *MockWaitlistRepository) PopNext(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) (*models.WaitlistEntry, error) {
+	args := m.Called(ctx, classID, date)
+	entry, _ := args.Get(0).(*models.WaitlistEntry)
+	return entry, args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Requeue(ctx context.Context, id primitive.ObjectID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockWaitlistRepository) MarkPromoted(ctx context.Context, id primitive.ObjectID, bookingID primitive.ObjectID) error {
+	return m.Called(ctx, id, bookingID).Error(0)
+}
+
+func (m *MockWaitlistRepository) RemoveWaiting(ctx context.Context, classID primitive.ObjectID) (int, error) {
+	args := m.Called(ctx, classID)
+	return args.Int(0), args.Error(1)
+}
+
 func TestBookClassHandler(t *testing.T) {
 	mockRepo := new(MockBookingRepository)
-	handler := NewBookingHandler(mockRepo)
+	mockClassRepo := new(MockClassRepository)
+	mockMemberRepo := new(MockMemberRepository)
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	handler := NewBookingHandler(service.NewBookingService(mockRepo, mockClassRepo, mockMemberRepo, mockWaitlistRepo))
 	mockStartDate := models.CustomDate(time.Now())
+	mockClass := &models.Class{
+		ID:        primitive.NewObjectID(),
+		Name:      "Yoga Class",
+		StartDate: mockStartDate,
+		EndDate:   models.CustomDate(time.Now().Add(24 * time.Hour)),
+		Capacity:  10,
+	}
+	mockMember := &models.Member{ID: primitive.NewObjectID(), Name: "John Doe", Status: models.MemberStatusActive}
 
 	tests := []struct {
 		name           string
 		requestBody    models.Booking
-		mockError      error
-		expectedStatus int
-		expectedError  string
+		waitlist       bool
+		mockClass      *models.Class
+		mockClassError error
+		// mockMember defaults to an active member when nil
+		mockMember      *models.Member
+		mockMemberError error
+		mockError       error
+		expectedStatus  int
+		expectedError   string
+		// expectedClassName is checked against the created booking when set
+		expectedClassName string
 	}{
 		{
 			name: "Valid booking creation",
 			requestBody: models.Booking{
-				ClassID:    primitive.NewObjectID(),
-				ClassName:  "Yoga Class",
-				MemberName: "John Doe",
-				Date:       mockStartDate,
+				ClassID:   mockClass.ID,
+				ClassName: "Yoga Class",
+				MemberID:  mockMember.ID,
+				Date:      mockStartDate,
 			},
+			mockClass:      mockClass,
 			mockError:      nil,
 			expectedStatus: http.StatusCreated,
 			expectedError:  "",
@@ -57,25 +139,176 @@ func TestBookClassHandler(t *testing.T) {
 		{
 			name: "Missing class ID",
 			requestBody: models.Booking{
-				ClassName:  "Yoga Class",
+				ClassName: "Yoga Class",
+				MemberID:  mockMember.ID,
+				Date:      mockStartDate,
+			},
+			mockError:      nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name: "Missing member ID",
+			requestBody: models.Booking{
+				ClassID:    mockClass.ID,
 				MemberName: "John Doe",
 				Date:       mockStartDate,
 			},
-			mockError:      nil,
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "Validation failed",
 		},
+		{
+			name: "Unknown member",
+			requestBody: models.Booking{
+				ClassID:  mockClass.ID,
+				MemberID: primitive.NewObjectID(),
+				Date:     mockStartDate,
+			},
+			mockClass:       mockClass,
+			mockMemberError: storage.ErrNotFound,
+			expectedStatus:  http.StatusNotFound,
+			expectedError:   "Member not found",
+		},
+		{
+			name: "Inactive member",
+			requestBody: models.Booking{
+				ClassID:  mockClass.ID,
+				MemberID: mockMember.ID,
+				Date:     mockStartDate,
+			},
+			mockClass:      mockClass,
+			mockMember:     &models.Member{ID: mockMember.ID, Name: "John Doe", Status: models.MemberStatusInactive},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name: "Class name is derived from the stored class",
+			requestBody: models.Booking{
+				ClassID:   mockClass.ID,
+				ClassName: "Spoofed Class",
+				MemberID:  mockMember.ID,
+				Date:      mockStartDate,
+			},
+			mockClass:         mockClass,
+			mockError:         nil,
+			expectedStatus:    http.StatusCreated,
+			expectedError:     "",
+			expectedClassName: "Yoga Class",
+		},
+		{
+			name: "Booking date before class start",
+			requestBody: models.Booking{
+				ClassID:  mockClass.ID,
+				MemberID: mockMember.ID,
+				Date:     models.CustomDate(time.Now().AddDate(0, 0, -1)),
+			},
+			mockClass:      mockClass,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name: "Booking date after class end",
+			requestBody: models.Booking{
+				ClassID:  mockClass.ID,
+				MemberID: mockMember.ID,
+				Date:     models.CustomDate(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			mockClass:      mockClass,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name: "Unknown class",
+			requestBody: models.Booking{
+				ClassID:   primitive.NewObjectID(),
+				ClassName: "Yoga Class",
+				MemberID:  mockMember.ID,
+				Date:      mockStartDate,
+			},
+			mockClassError: storage.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Class not found",
+		},
+		{
+			name: "Class fully booked",
+			requestBody: models.Booking{
+				ClassID:   mockClass.ID,
+				ClassName: "Yoga Class",
+				MemberID:  mockMember.ID,
+				Date:      mockStartDate,
+			},
+			mockClass:      mockClass,
+			mockError:      storage.ErrClassFull,
+			expectedStatus: http.StatusConflict,
+			expectedError:  storage.ErrClassFull.Error(),
+		},
+		{
+			name: "Member already booked on the date",
+			requestBody: models.Booking{
+				ClassID:  mockClass.ID,
+				MemberID: mockMember.ID,
+				Date:     mockStartDate,
+			},
+			waitlist:       true,
+			mockClass:      mockClass,
+			mockError:      storage.ErrAlreadyBooked,
+			expectedStatus: http.StatusConflict,
+			expectedError:  storage.ErrAlreadyBooked.Error(),
+		},
+		{
+			name: "Member already on the waitlist for the date",
+			requestBody: models.Booking{
+				ClassID:  mockClass.ID,
+				MemberID: mockMember.ID,
+				Date:     mockStartDate,
+			},
+			waitlist:       true,
+			mockClass:      mockClass,
+			mockError:      storage.ErrAlreadyWaitlisted,
+			expectedStatus: http.StatusConflict,
+			expectedError:  storage.ErrAlreadyWaitlisted.Error(),
+		},
+		{
+			name: "Class fully booked with waitlist opt-in",
+			requestBody: models.Booking{
+				ClassID:  mockClass.ID,
+				MemberID: mockMember.ID,
+				Date:     mockStartDate,
+			},
+			waitlist:       true,
+			mockClass:      mockClass,
+			mockError:      storage.ErrClassFull,
+			expectedStatus: http.StatusAccepted,
+			expectedError:  "",
+		},
 	}
 
 	for _, tt := range tests {
+		//clear the mock expectations
+		mockRepo.Calls = nil
+		mockRepo.ExpectedCalls = nil
+		mockClassRepo.Calls = nil
+		mockClassRepo.ExpectedCalls = nil
+		mockMemberRepo.Calls = nil
+		mockMemberRepo.ExpectedCalls = nil
+		mockWaitlistRepo.Calls = nil
+		mockWaitlistRepo.ExpectedCalls = nil
+
 		t.Run(tt.name, func(t *testing.T) {
+			mockClassRepo.On("GetByID", mock.Anything, tt.requestBody.ClassID).Return(tt.mockClass, tt.mockClassError)
+			member := tt.mockMember
+			if member == nil && tt.mockMemberError == nil {
+				member = mockMember
+			}
+			mockMemberRepo.On("GetByID", mock.Anything, tt.requestBody.MemberID).Return(member, tt.mockMemberError)
+			mockWaitlistRepo.On("Add", mock.Anything, mock.Anything).Return(primitive.NewObjectID(), 3, nil)
 			if tt.mockError == nil {
-				mockRepo.On("Create", mock.Anything, mock.Anything).Return(primitive.NewObjectID(), nil)
+				mockRepo.On("Create", mock.Anything, mock.Anything, mockClass.Capacity).Return(primitive.NewObjectID(), nil)
 			} else {
-				mockRepo.On("Create", mock.Anything, mock.Anything).Return(primitive.NilObjectID, tt.mockError)
+				mockRepo.On("Create", mock.Anything, mock.Anything, mockClass.Capacity).Return(primitive.NilObjectID, tt.mockError)
 			}
 
-			reqBody, err := json.Marshal(tt.requestBody)
+			reqBody, err := json.Marshal(bookingRequest{Booking: tt.requestBody, Waitlist: tt.waitlist})
 			assert.NoError(t, err)
 
 			req := httptest.NewRequest(http.MethodPost, "/book-class", bytes.NewBuffer(reqBody))
@@ -92,27 +325,52 @@ func TestBookClassHandler(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Contains(t, response.Message, tt.expectedError)
 			}
+
+			if tt.expectedClassName != "" {
+				var response struct {
+					Data models.Booking `json:"data"`
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedClassName, response.Data.ClassName)
+				assert.Equal(t, mockMember.Name, response.Data.MemberName)
+			}
+
+			if tt.waitlist && tt.expectedStatus == http.StatusAccepted {
+				var response struct {
+					Data models.WaitlistEntry `json:"data"`
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, 3, response.Data.Position)
+				assert.Equal(t, models.WaitlistStatusWaiting, response.Data.Status)
+			}
 		})
 	}
 }
 
 func TestGetBookingsHandler(t *testing.T) {
 	mockRepo := new(MockBookingRepository)
-	handler := NewBookingHandler(mockRepo)
+	handler := NewBookingHandler(service.NewBookingService(mockRepo, new(MockClassRepository), new(MockMemberRepository), new(MockWaitlistRepository)))
 	mockStartDate := models.CustomDate(time.Now())
+	classID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+	from := models.CustomDate(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	to := models.CustomDate(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
 	tests := []struct {
 		name           string
+		query          BookingQuery
+		expectedFilter storage.BookingFilter
 		mockBookings   []models.Booking
 		mockError      error
 		expectedStatus int
 		expectedError  string
 	}{
 		{
-			name:           "No bookings found",
-			mockBookings:   []models.Booking{},
+			name:           "No bookings is an empty list",
+			mockBookings:   []models.Booking(nil),
 			mockError:      nil,
-			expectedStatus: http.StatusNotFound,
-			expectedError:  "No bookings found",
+			expectedStatus: http.StatusOK,
 		},
 		{
 			name: "Bookings retrieved successfully",
@@ -129,6 +387,38 @@ func TestGetBookingsHandler(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedError:  "",
 		},
+		{
+			name:           "Filter by class, member ID, date and status",
+			query:          BookingQuery{ClassID: classID.Hex(), Member: memberID.Hex(), Date: &mockStartDate, Status: models.BookingStatusActive},
+			expectedFilter: storage.BookingFilter{ClassID: classID, MemberID: memberID, Date: &mockStartDate, Status: models.BookingStatusActive},
+			mockBookings:   []models.Booking{{ID: primitive.NewObjectID(), ClassID: classID, MemberID: memberID, Date: mockStartDate}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Filter by member name",
+			query:          BookingQuery{Member: "John Doe"},
+			expectedFilter: storage.BookingFilter{MemberName: "John Doe"},
+			mockBookings:   []models.Booking{{ID: primitive.NewObjectID(), MemberName: "John Doe", Date: mockStartDate}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid class ID",
+			query:          BookingQuery{ClassID: "not-an-id"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Invalid status",
+			query:          BookingQuery{Status: "pending"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "From after to",
+			query:          BookingQuery{From: &from, To: &to},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -137,21 +427,206 @@ func TestGetBookingsHandler(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
 
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo.On("GetAll", mock.Anything).Return(tt.mockBookings, tt.mockError)
+			mockRepo.On("GetAll", mock.Anything, tt.expectedFilter, mock.Anything).Return(tt.mockBookings, tt.mockError)
 
 			req := httptest.NewRequest(http.MethodGet, "/get-bookings", nil)
 			rec := httptest.NewRecorder()
 
-			handler.GetBookingsHandler(rec, req)
+			handler.GetBookingsHandler(rec, req, tt.query)
 
 			assert.Equal(t, tt.expectedStatus, rec.Code)
 
+			if tt.expectedStatus == http.StatusBadRequest {
+				mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				mockRepo.AssertCalled(t, "GetAll", mock.Anything, tt.expectedFilter, mock.Anything)
+			}
+
 			if tt.expectedError != "" {
 				var response models.GlobalResponse
 				err := json.Unmarshal(rec.Body.Bytes(), &response)
 				assert.NoError(t, err)
 				assert.Contains(t, response.Message, tt.expectedError)
+				return
+			}
+
+			var response struct {
+				Data []models.Booking `json:"data"`
 			}
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.NotNil(t, response.Data, "data must be a list, not null")
+			assert.Len(t, response.Data, len(tt.mockBookings))
+		})
+	}
+}
+
+func TestCancelBookingHandler(t *testing.T) {
+	mockRepo := new(MockBookingRepository)
+	mockClassRepo := new(MockClassRepository)
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	handler := NewBookingHandler(service.NewBookingService(mockRepo, mockClassRepo, new(MockMemberRepository), mockWaitlistRepo))
+	bookingID := primitive.NewObjectID()
+	cancelledAt := time.Now()
+
+	tests := []struct {
+		name           string
+		id             string
+		mockBooking    *models.Booking
+		mockError      error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "Booking cancelled successfully",
+			id:   bookingID.Hex(),
+			mockBooking: &models.Booking{
+				ID:                 bookingID,
+				ClassID:            primitive.NewObjectID(),
+				ClassName:          "Yoga Class",
+				MemberName:         "John Doe",
+				Date:               models.CustomDate(time.Now()),
+				Status:             models.BookingStatusCancelled,
+				CancelledAt:        &cancelledAt,
+				CancellationReason: "Feeling unwell",
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid booking ID",
+			id:             "not-an-id",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid booking ID",
+		},
+		{
+			name:           "Booking not found",
+			id:             bookingID.Hex(),
+			mockError:      storage.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Booking not found",
+		},
+		{
+			name:           "Booking already cancelled",
+			id:             bookingID.Hex(),
+			mockError:      storage.ErrAlreadyCancelled,
+			expectedStatus: http.StatusConflict,
+			expectedError:  storage.ErrAlreadyCancelled.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		//clear the mock expectations
+		mockRepo.Calls = nil
+		mockRepo.ExpectedCalls = nil
+		mockClassRepo.Calls = nil
+		mockClassRepo.ExpectedCalls = nil
+		mockWaitlistRepo.Calls = nil
+		mockWaitlistRepo.ExpectedCalls = nil
+
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.On("Cancel", mock.Anything, bookingID, "Feeling unwell").Return(tt.mockBooking, tt.mockError)
+			// Nobody is waiting, so the released spot is not handed on
+			mockClassRepo.On("GetByID", mock.Anything, mock.Anything).Return(&models.Class{Capacity: 10}, nil)
+			mockWaitlistRepo.On("PopNext", mock.Anything, mock.Anything, mock.Anything).Return(nil, storage.ErrNotFound)
+
+			req := httptest.NewRequest(http.MethodDelete, "/bookings/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+
+			handler.CancelBookingHandler(rec, req, tt.id, "Feeling unwell")
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var response models.GlobalResponse
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if tt.expectedError != "" {
+				assert.Contains(t, response.Message, tt.expectedError)
+			} else {
+				assert.Equal(t, util.StatusSuccess, response.Status)
+			}
+		})
+	}
+}
+
+func TestGetWaitlistHandler(t *testing.T) {
+	mockClassRepo := new(MockClassRepository)
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	handler := NewBookingHandler(service.NewBookingService(new(MockBookingRepository), mockClassRepo, new(MockMemberRepository), mockWaitlistRepo))
+	classID := primitive.NewObjectID()
+	date := models.CustomDate(time.Now())
+
+	tests := []struct {
+		name           string
+		classID        string
+		mockClassError error
+		mockEntries    []models.WaitlistEntry
+		expectedStatus int
+		expectedError  string
+		expectedCount  int
+	}{
+		{
+			name:    "Waitlist retrieved in queue order",
+			classID: classID.Hex(),
+			mockEntries: []models.WaitlistEntry{
+				{ID: primitive.NewObjectID(), ClassID: classID, Date: date, MemberName: "John Doe", Status: models.WaitlistStatusWaiting, Position: 1},
+				{ID: primitive.NewObjectID(), ClassID: classID, Date: date, MemberName: "Jane Doe", Status: models.WaitlistStatusWaiting, Position: 2},
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "Empty waitlist",
+			classID:        classID.Hex(),
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name:           "Invalid class ID",
+			classID:        "not-an-id",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid class ID",
+		},
+		{
+			name:           "Unknown class",
+			classID:        classID.Hex(),
+			mockClassError: storage.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Class not found",
+		},
+	}
+
+	for _, tt := range tests {
+		//clear the mock expectations
+		mockClassRepo.Calls = nil
+		mockClassRepo.ExpectedCalls = nil
+		mockWaitlistRepo.Calls = nil
+		mockWaitlistRepo.ExpectedCalls = nil
+
+		t.Run(tt.name, func(t *testing.T) {
+			mockClassRepo.On("GetByID", mock.Anything, classID).Return(&models.Class{ID: classID}, tt.mockClassError)
+			mockWaitlistRepo.On("List", mock.Anything, classID, date).Return(tt.mockEntries, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/classes/"+tt.classID+"/occurrences/"+date.String()+"/waitlist", nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetWaitlistHandler(rec, req, tt.classID, date)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var response models.GlobalResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Contains(t, response.Message, tt.expectedError)
+				return
+			}
+
+			var response struct {
+				Data []models.WaitlistEntry `json:"data"`
+			}
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Len(t, response.Data, tt.expectedCount)
 		})
 	}
 }
@@ -4,56 +4,129 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/service"
 	"github.com/sinhaseemant/glofox-backend/internal/storage"
 	"github.com/sinhaseemant/glofox-backend/models"
 	"github.com/sinhaseemant/glofox-backend/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // ClassHandlerInterface defines the contract for ClassHandler
 type ClassHandlerInterface interface {
 	CreateClassHandler(w http.ResponseWriter, r *http.Request)
-	GetClassesHandler(w http.ResponseWriter, r *http.Request)
+	GetClassesHandler(w http.ResponseWriter, r *http.Request, query ClassQuery)
+	GetClassHandler(w http.ResponseWriter, r *http.Request, id string)
+	UpdateClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool)
+	PatchClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool)
+	DeleteClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool)
+	GetClassOccurrencesHandler(w http.ResponseWriter, r *http.Request, id string, from, to *models.CustomDate)
 }
 
 // ClassHandler struct for dependency injection
 type ClassHandler struct {
-	Repo storage.ClassRepositoryInterface
+	Service *service.ClassService
 }
 
 // NewClassHandler initializes a handler with DI
-func NewClassHandler(repo storage.ClassRepositoryInterface) ClassHandlerInterface {
-	return &ClassHandler{Repo: repo}
+func NewClassHandler(svc *service.ClassService) ClassHandlerInterface {
+	return &ClassHandler{Service: svc}
 }
 
-// CreateClassHandler handles class creation
-func (h *ClassHandler) CreateClassHandler(w http.ResponseWriter, r *http.Request) {
-	var req models.Class
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
-		http.Error(w, string(resStr), http.StatusBadRequest)
-		return
+// classPatch is the PATCH /classes/{id} body, only the fields present are applied
+type classPatch struct {
+	Name            *string                `json:"name"`
+	StartDate       *models.CustomDate     `json:"start_date"`
+	EndDate         *models.CustomDate     `json:"end_date"`
+	Capacity        *int                   `json:"capacity"`
+	StartTime       *string                `json:"start_time"`
+	DurationMinutes *int                   `json:"duration_minutes"`
+	Timezone        *string                `json:"timezone"`
+	Recurrence      *models.RecurrenceRule `json:"recurrence"`
+}
+
+// ClassQuery holds the GET /classes query parameters, all optional
+type ClassQuery struct {
+	PageQuery
+	Name     string
+	ActiveOn *models.CustomDate
+	From     *models.CustomDate
+	To       *models.CustomDate
+	MinSpots *int
+	Sort     string // name, start_date or capacity, prefixed with "-" for descending order
+}
+
+// search validates the query and translates it to a class search and page
+func (q ClassQuery) search() (service.ClassSearch, storage.Page, []string) {
+	var validationErrors []string
+	search := service.ClassSearch{ClassFilter: storage.ClassFilter{Name: strings.TrimSpace(q.Name), From: q.From, To: q.To}}
+
+	if q.ActiveOn != nil {
+		if q.From != nil || q.To != nil {
+			validationErrors = append(validationErrors, "active_on cannot be combined with from or to")
+		}
+		search.From, search.To = q.ActiveOn, q.ActiveOn
+	}
+	if q.From != nil && q.To != nil && q.From.String() > q.To.String() {
+		validationErrors = append(validationErrors, "from must not be after to")
 	}
+	if q.MinSpots != nil {
+		if *q.MinSpots < 1 {
+			validationErrors = append(validationErrors, "min_spots must be at least 1")
+		}
+		if search.From == nil || search.To == nil {
+			validationErrors = append(validationErrors, "min_spots needs active_on, or from and to")
+		}
+		search.MinSpots = *q.MinSpots
+	}
+
+	sort, ok := parseSort(q.Sort, "name", "start_date", "capacity")
+	if !ok {
+		validationErrors = append(validationErrors, "sort must be name, start_date or capacity, optionally prefixed with -")
+	}
+	page, pageErrors := q.page(sort)
+	return search, page, append(validationErrors, pageErrors...)
+}
 
-	// Validate the class data
+// validateClass returns the validation errors of a class, if any
+func validateClass(class models.Class) []string {
 	var validationErrors []string
 
-	if req.Name == "" {
+	if class.Name == "" {
 		validationErrors = append(validationErrors, "Class name is required")
 	}
-	if req.StartDate.IsZero() {
+	if class.StartDate.IsZero() {
 		validationErrors = append(validationErrors, "Start date is required")
 	}
-	if req.EndDate.IsZero() {
+	if class.EndDate.IsZero() {
 		validationErrors = append(validationErrors, "End date is required")
 	}
-	if req.Capacity <= 0 {
+	if !class.StartDate.IsZero() && !class.EndDate.IsZero() && class.EndDate.String() < class.StartDate.String() {
+		validationErrors = append(validationErrors, "End date must not be before start date")
+	}
+	if class.Capacity <= 0 {
 		validationErrors = append(validationErrors, "Capacity must be greater than 0")
 	}
+	validationErrors = append(validationErrors, class.ValidateSchedule()...)
+	if class.Recurrence != nil {
+		validationErrors = append(validationErrors, class.Recurrence.Validate()...)
+	}
+	return validationErrors
+}
+
+// CreateClassHandler handles class creation
+func (h *ClassHandler) CreateClassHandler(w http.ResponseWriter, r *http.Request) {
+	var req models.Class
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
 
 	// If there are validation errors, return them in the response
-	if len(validationErrors) > 0 {
+	if validationErrors := validateClass(req); len(validationErrors) > 0 {
 		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErrors, http.StatusBadRequest, errors.New("Validation failed")))
 		http.Error(w, string(resStr), http.StatusBadRequest)
 		return
@@ -61,7 +134,7 @@ func (h *ClassHandler) CreateClassHandler(w http.ResponseWriter, r *http.Request
 	ctx := r.Context()
 
 	// Insert class into MongoDB
-	id, err := h.Repo.Create(ctx, &req)
+	id, err := h.Service.Create(ctx, &req)
 	if err != nil {
 		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
 		http.Error(w, string(resStr), http.StatusInternalServerError)
@@ -69,34 +142,225 @@ func (h *ClassHandler) CreateClassHandler(w http.ResponseWriter, r *http.Request
 	}
 	req.ID = id
 
-	log.Info().Msgf("Class created: %+v", req)
+	log.Info().Ctx(r.Context()).Msgf("Class created: %+v", req)
 	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, req, http.StatusCreated, nil))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	w.Write(resStr)
 }
 
-func (h *ClassHandler) GetClassesHandler(w http.ResponseWriter, r *http.Request) {
+// GetClassesHandler retrieves a page of the classes matching the query parameters
+func (h *ClassHandler) GetClassesHandler(w http.ResponseWriter, r *http.Request, query ClassQuery) {
+	search, page, validationErrors := query.search()
+	if len(validationErrors) > 0 {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErrors, http.StatusBadRequest, errors.New("Validation failed")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
 	ctx := r.Context()
 
 	// Fetch classes from MongoDB
-	classes, err := h.Repo.GetAll(ctx)
+	classes, next, err := h.Service.GetAll(ctx, search, page)
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErr.Errors, http.StatusBadRequest, validationErr))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
 		http.Error(w, string(resStr), http.StatusInternalServerError)
 		return
 	}
 
-	// If no classes are found, return a 404 response
-	if len(classes) == 0 {
-		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, errors.New("No classes found")))
-		http.Error(w, string(resStr), http.StatusNotFound)
+	// An empty page is still a page, clients get an empty list rather than null
+	if classes == nil {
+		classes = []models.Class{}
+	}
+
+	// Return the page of classes
+	w.Header().Set("Content-Type", "application/json")
+	response := util.SendGlobalResponse(util.StatusSuccess, classes, http.StatusOK, nil)
+	response.NextCursor = next
+	resStr, _ := json.Marshal(response)
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// GetClassHandler retrieves a single class
+func (h *ClassHandler) GetClassHandler(w http.ResponseWriter, r *http.Request, id string) {
+	classID, ok := parseClassID(w, id)
+	if !ok {
 		return
 	}
 
-	// Return the list of classes
+	class, err := h.Service.Get(r.Context(), classID)
+	if err != nil {
+		writeClassError(w, err)
+		return
+	}
+
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, class, http.StatusOK, nil))
 	w.Header().Set("Content-Type", "application/json")
-	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, classes, http.StatusOK, nil))
 	w.WriteHeader(http.StatusOK)
 	w.Write(resStr)
 }
+
+// UpdateClassHandler replaces every field of a class
+func (h *ClassHandler) UpdateClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool) {
+	classID, ok := parseClassID(w, id)
+	if !ok {
+		return
+	}
+
+	var req models.Class
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+	req.ID = classID
+
+	h.updateClass(w, r, &req, force)
+}
+
+// PatchClassHandler updates the fields of a class present in the request body
+func (h *ClassHandler) PatchClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool) {
+	classID, ok := parseClassID(w, id)
+	if !ok {
+		return
+	}
+
+	var req classPatch
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	class, err := h.Service.Get(r.Context(), classID)
+	if err != nil {
+		writeClassError(w, err)
+		return
+	}
+	if req.Name != nil {
+		class.Name = *req.Name
+	}
+	if req.StartDate != nil {
+		class.StartDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		class.EndDate = *req.EndDate
+	}
+	if req.Capacity != nil {
+		class.Capacity = *req.Capacity
+	}
+	if req.StartTime != nil {
+		class.StartTime = *req.StartTime
+	}
+	if req.DurationMinutes != nil {
+		class.DurationMinutes = *req.DurationMinutes
+	}
+	if req.Timezone != nil {
+		class.Timezone = *req.Timezone
+	}
+	if req.Recurrence != nil {
+		class.Recurrence = req.Recurrence
+	}
+
+	h.updateClass(w, r, class, force)
+}
+
+// updateClass validates and stores a full class, shared by PUT and PATCH
+func (h *ClassHandler) updateClass(w http.ResponseWriter, r *http.Request, class *models.Class, force bool) {
+	if validationErrors := validateClass(*class); len(validationErrors) > 0 {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErrors, http.StatusBadRequest, errors.New("Validation failed")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	cancelled, err := h.Service.Update(r.Context(), class, force)
+	if err != nil {
+		writeClassError(w, err)
+		return
+	}
+
+	log.Info().Ctx(r.Context()).Msgf("Class updated: %+v, %d bookings cancelled", *class, len(cancelled))
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, class, http.StatusOK, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// DeleteClassHandler deletes a class, cancelling its bookings when forced
+func (h *ClassHandler) DeleteClassHandler(w http.ResponseWriter, r *http.Request, id string, force bool) {
+	classID, ok := parseClassID(w, id)
+	if !ok {
+		return
+	}
+
+	cancelled, err := h.Service.Delete(r.Context(), classID, force)
+	if err != nil {
+		writeClassError(w, err)
+		return
+	}
+
+	log.Info().Ctx(r.Context()).Msgf("Class deleted: %s, %d bookings cancelled", id, len(cancelled))
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, nil, http.StatusOK, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// GetClassOccurrencesHandler lists the sessions of a class with their remaining spots
+func (h *ClassHandler) GetClassOccurrencesHandler(w http.ResponseWriter, r *http.Request, id string, from, to *models.CustomDate) {
+	classID, ok := parseClassID(w, id)
+	if !ok {
+		return
+	}
+
+	occurrences, err := h.Service.Occurrences(r.Context(), classID, from, to)
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErr.Errors, http.StatusBadRequest, validationErr))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeClassError(w, err)
+		return
+	}
+
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, occurrences, http.StatusOK, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// parseClassID parses a class ID path parameter, writing a 400 response if it is invalid
+func parseClassID(w http.ResponseWriter, id string) (primitive.ObjectID, bool) {
+	classID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, errors.New("Invalid class ID")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return primitive.NilObjectID, false
+	}
+	return classID, true
+}
+
+// writeClassError maps errors from the class service to responses
+func writeClassError(w http.ResponseWriter, err error) {
+	var affectedErr *service.AffectedBookingsError
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, errors.New("Class not found")))
+		http.Error(w, string(resStr), http.StatusNotFound)
+	case errors.As(err, &affectedErr):
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, affectedErr.BookingIDs(), http.StatusConflict, affectedErr))
+		http.Error(w, string(resStr), http.StatusConflict)
+	default:
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
+		http.Error(w, string(resStr), http.StatusInternalServerError)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sinhaseemant/glofox-backend/internal/service"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
 	"github.com/sinhaseemant/glofox-backend/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,14 +27,28 @@ func (m *MockClassRepository) Create(ctx context.Context, class *models.Class) (
 	return primitive.NewObjectID(), args.Error(1)
 }
 
-func (m *MockClassRepository) GetAll(ctx context.Context) ([]models.Class, error) {
-	args := m.Called(ctx)
+func (m *MockClassRepository) GetAll(ctx context.Context, filter storage.ClassFilter, page storage.Page) ([]models.Class, error) {
+	args := m.Called(ctx, filter, page)
 	return args.Get(0).([]models.Class), args.Error(1)
 }
 
+func (m *MockClassRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Class, error) {
+	args := m.Called(ctx, id)
+	class, _ := args.Get(0).(*models.Class)
+	return class, args.Error(1)
+}
+
+func (m *MockClassRepository) Update(ctx context.Context, class *models.Class) error {
+	return m.Called(ctx, class).Error(0)
+}
+
+func (m *MockClassRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
 func TestCreateClassHandler(t *testing.T) {
 	mockRepo := new(MockClassRepository)
-	handler := NewClassHandler(mockRepo)
+	handler := NewClassHandler(service.NewClassService(mockRepo, new(MockBookingRepository), new(MockWaitlistRepository)))
 
 	mockStartDate := models.CustomDate(time.Now())
 	mockEndDate := models.CustomDate(time.Now().Add(24 * time.Hour))
@@ -100,26 +116,38 @@ func TestCreateClassHandler(t *testing.T) {
 
 func TestGetClassesHandler(t *testing.T) {
 	mockRepo := new(MockClassRepository)
-	handler := NewClassHandler(mockRepo)
+	handler := NewClassHandler(service.NewClassService(mockRepo, new(MockBookingRepository), new(MockWaitlistRepository)))
 
 	mockStartDate := models.CustomDate(time.Now())
 	mockEndDate := models.CustomDate(time.Now().Add(24 * time.Hour))
+	first := models.Class{ID: primitive.NewObjectID(), Name: "Yoga Class"}
+	second := models.Class{ID: primitive.NewObjectID(), Name: "Pilates Class"}
+	afterID := primitive.NewObjectID()
+	afterCursor, _ := service.EncodeCursor(models.Class{ID: afterID}, storage.Sort{})
+	nextCursor, _ := service.EncodeCursor(first, storage.Sort{})
+	limit, zero := 1, 0
+	defaultPage := storage.Page{Limit: service.DefaultPageLimit + 1}
 	tests := []struct {
 		name           string
+		query          ClassQuery
+		expectedFilter storage.ClassFilter
+		expectedPage   storage.Page
 		mockClasses    []models.Class
 		mockError      error
 		expectedStatus int
 		expectedError  string
+		expectedNext   string
 	}{
 		{
-			name:           "No classes found",
-			mockClasses:    []models.Class{},
+			name:           "No classes is an empty list",
+			expectedPage:   defaultPage,
+			mockClasses:    []models.Class(nil),
 			mockError:      nil,
-			expectedStatus: http.StatusNotFound,
-			expectedError:  "No classes found",
+			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "Classes retrieved successfully",
+			name:         "Classes retrieved successfully",
+			expectedPage: defaultPage,
 			mockClasses: []models.Class{
 				{
 					ID:        primitive.NewObjectID(),
@@ -133,6 +161,66 @@ func TestGetClassesHandler(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedError:  "",
 		},
+		{
+			name:           "Page with more classes after it",
+			query:          ClassQuery{PageQuery: PageQuery{Limit: &limit, Cursor: afterCursor}},
+			expectedPage:   storage.Page{After: afterID, Limit: 2},
+			mockClasses:    []models.Class{first, second},
+			expectedStatus: http.StatusOK,
+			expectedNext:   nextCursor,
+		},
+		{
+			name:           "Filter by name, sorted by name descending",
+			query:          ClassQuery{Name: " yoga ", Sort: "-name"},
+			expectedFilter: storage.ClassFilter{Name: "yoga"},
+			expectedPage:   storage.Page{Sort: storage.Sort{Field: "name", Desc: true}, Limit: service.DefaultPageLimit + 1},
+			mockClasses:    []models.Class{first},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Filter by date window",
+			query:          ClassQuery{From: &mockStartDate},
+			expectedFilter: storage.ClassFilter{From: &mockStartDate},
+			expectedPage:   defaultPage,
+			mockClasses:    []models.Class{first},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Limit out of range",
+			query:          ClassQuery{PageQuery: PageQuery{Limit: &zero}},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Invalid cursor",
+			query:          ClassQuery{PageQuery: PageQuery{Cursor: "not-a-cursor"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Cursor of another sort",
+			query:          ClassQuery{PageQuery: PageQuery{Cursor: afterCursor}, Sort: "name"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Invalid sort",
+			query:          ClassQuery{Sort: "popularity"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Minimum spots without a window",
+			query:          ClassQuery{MinSpots: &limit},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Active on combined with from",
+			query:          ClassQuery{ActiveOn: &mockStartDate, From: &mockStartDate},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -141,21 +229,362 @@ func TestGetClassesHandler(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
 
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo.On("GetAll", mock.Anything).Return(tt.mockClasses, tt.mockError)
+			mockRepo.On("GetAll", mock.Anything, tt.expectedFilter, tt.expectedPage).Return(tt.mockClasses, tt.mockError)
 
 			req := httptest.NewRequest(http.MethodGet, "/get-classes", nil)
 			rec := httptest.NewRecorder()
 
-			handler.GetClassesHandler(rec, req)
+			handler.GetClassesHandler(rec, req, tt.query)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var response models.GlobalResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Contains(t, response.Message, tt.expectedError)
+				return
+			}
+
+			var response struct {
+				Data       []models.Class `json:"data"`
+				NextCursor string         `json:"next_cursor"`
+			}
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.NotNil(t, response.Data, "data must be a list, not null")
+			assert.Equal(t, tt.expectedNext, response.NextCursor)
+			if tt.expectedNext != "" {
+				assert.Len(t, response.Data, limit)
+			}
+		})
+	}
+}
+
+func TestGetClassHandler(t *testing.T) {
+	mockRepo := new(MockClassRepository)
+	handler := NewClassHandler(service.NewClassService(mockRepo, new(MockBookingRepository), new(MockWaitlistRepository)))
+	classID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		id             string
+		mockClass      *models.Class
+		mockError      error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "Class retrieved successfully",
+			id:             classID.Hex(),
+			mockClass:      &models.Class{ID: classID, Name: "Yoga Class", Capacity: 10},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid class ID",
+			id:             "not-an-id",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid class ID",
+		},
+		{
+			name:           "Class not found",
+			id:             classID.Hex(),
+			mockError:      storage.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Class not found",
+		},
+	}
+
+	for _, tt := range tests {
+		//clear the mock expectations
+		mockRepo.Calls = nil
+		mockRepo.ExpectedCalls = nil
+
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.On("GetByID", mock.Anything, classID).Return(tt.mockClass, tt.mockError)
+
+			req := httptest.NewRequest(http.MethodGet, "/classes/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetClassHandler(rec, req, tt.id)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var response models.GlobalResponse
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if tt.expectedError != "" {
+				assert.Contains(t, response.Message, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestUpdateClassHandler(t *testing.T) {
+	// Only upcoming bookings are affected by a change
+	year := time.Now().Year() + 1
+	startDate := models.CustomDate(time.Date(year, 3, 1, 0, 0, 0, 0, time.UTC))
+	endDate := models.CustomDate(time.Date(year, 3, 31, 0, 0, 0, 0, time.UTC))
+	stored := &models.Class{ID: primitive.NewObjectID(), Name: "Yoga Class", StartDate: startDate, EndDate: endDate, Capacity: 2}
+	bookingDate := models.CustomDate(time.Date(year, 3, 10, 0, 0, 0, 0, time.UTC))
+	activeBookings := []models.Booking{
+		{ID: primitive.NewObjectID(), ClassID: stored.ID, Date: bookingDate, Status: models.BookingStatusActive},
+		{ID: primitive.NewObjectID(), ClassID: stored.ID, Date: bookingDate, Status: models.BookingStatusActive},
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    models.Class
+		force          bool
+		expectedStatus int
+		expectedError  string
+		expectedCancel int
+	}{
+		{
+			name:           "Rename keeps bookings",
+			requestBody:    models.Class{Name: "Power Yoga", StartDate: startDate, EndDate: endDate, Capacity: 2},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Capacity below bookings is rejected",
+			requestBody:    models.Class{Name: "Yoga Class", StartDate: startDate, EndDate: endDate, Capacity: 1},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "force=true",
+		},
+		{
+			name:           "Capacity below bookings with force cancels the latest booking",
+			requestBody:    models.Class{Name: "Yoga Class", StartDate: startDate, EndDate: endDate, Capacity: 1},
+			force:          true,
+			expectedStatus: http.StatusOK,
+			expectedCancel: 1,
+		},
+		{
+			name:           "Date range excluding bookings is rejected",
+			requestBody:    models.Class{Name: "Yoga Class", StartDate: models.CustomDate(time.Date(year, 3, 15, 0, 0, 0, 0, time.UTC)), EndDate: endDate, Capacity: 2},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "2 active bookings",
+		},
+		{
+			name:           "Invalid class data",
+			requestBody:    models.Class{Name: "Yoga Class", StartDate: endDate, EndDate: startDate, Capacity: 2},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockClassRepository)
+			mockBookingRepo := new(MockBookingRepository)
+			handler := NewClassHandler(service.NewClassService(mockRepo, mockBookingRepo, new(MockWaitlistRepository)))
+
+			mockRepo.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockBookingRepo.On("GetActiveByClass", mock.Anything, stored.ID, mock.Anything).Return(activeBookings, nil)
+			mockBookingRepo.On("Cancel", mock.Anything, mock.Anything, mock.Anything).Return(&activeBookings[1], nil)
+
+			reqBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/classes/"+stored.ID.Hex(), bytes.NewBuffer(reqBody))
+			rec := httptest.NewRecorder()
+
+			handler.UpdateClassHandler(rec, req, stored.ID.Hex(), tt.force)
 
 			assert.Equal(t, tt.expectedStatus, rec.Code)
 
+			var response models.GlobalResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if tt.expectedError != "" {
+				assert.Contains(t, response.Message, tt.expectedError)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			}
+			mockBookingRepo.AssertNumberOfCalls(t, "Cancel", tt.expectedCancel)
+			if tt.expectedCancel > 0 {
+				mockBookingRepo.AssertCalled(t, "Cancel", mock.Anything, activeBookings[1].ID, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPatchClassHandler(t *testing.T) {
+	stored := &models.Class{
+		ID:        primitive.NewObjectID(),
+		Name:      "Yoga Class",
+		StartDate: models.CustomDate(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:   models.CustomDate(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)),
+		Capacity:  10,
+	}
+	mockRepo := new(MockClassRepository)
+	mockBookingRepo := new(MockBookingRepository)
+	handler := NewClassHandler(service.NewClassService(mockRepo, mockBookingRepo, new(MockWaitlistRepository)))
+
+	mockRepo.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockBookingRepo.On("GetActiveByClass", mock.Anything, stored.ID, mock.Anything).Return([]models.Booking{}, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/classes/"+stored.ID.Hex(), bytes.NewBufferString(`{"name":"Power Yoga"}`))
+	rec := httptest.NewRecorder()
+
+	handler.PatchClassHandler(rec, req, stored.ID.Hex(), false)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(c *models.Class) bool {
+		return c.Name == "Power Yoga" && c.Capacity == 10 && c.StartDate == stored.StartDate
+	}))
+}
+
+func TestDeleteClassHandler(t *testing.T) {
+	classID := primitive.NewObjectID()
+	booking := models.Booking{ID: primitive.NewObjectID(), ClassID: classID, Status: models.BookingStatusActive}
+
+	tests := []struct {
+		name           string
+		force          bool
+		mockClassError error
+		mockBookings   []models.Booking
+		expectedStatus int
+		expectedError  string
+		expectDelete   bool
+	}{
+		{
+			name:           "Class without bookings is deleted",
+			mockBookings:   []models.Booking{},
+			expectedStatus: http.StatusOK,
+			expectDelete:   true,
+		},
+		{
+			name:           "Class with bookings is rejected",
+			mockBookings:   []models.Booking{booking},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "1 active bookings",
+		},
+		{
+			name:           "Class with bookings is deleted with force",
+			force:          true,
+			mockBookings:   []models.Booking{booking},
+			expectedStatus: http.StatusOK,
+			expectDelete:   true,
+		},
+		{
+			name:           "Class not found",
+			mockClassError: storage.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Class not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockClassRepository)
+			mockBookingRepo := new(MockBookingRepository)
+			mockWaitlistRepo := new(MockWaitlistRepository)
+			handler := NewClassHandler(service.NewClassService(mockRepo, mockBookingRepo, mockWaitlistRepo))
+
+			mockRepo.On("GetByID", mock.Anything, classID).Return(&models.Class{ID: classID}, tt.mockClassError)
+			mockRepo.On("Delete", mock.Anything, classID).Return(nil)
+			mockWaitlistRepo.On("RemoveWaiting", mock.Anything, classID).Return(2, nil)
+			mockBookingRepo.On("GetActiveByClass", mock.Anything, classID, mock.Anything).Return(tt.mockBookings, nil)
+			mockBookingRepo.On("Cancel", mock.Anything, booking.ID, mock.Anything).Return(&booking, nil)
+
+			req := httptest.NewRequest(http.MethodDelete, "/classes/"+classID.Hex(), nil)
+			rec := httptest.NewRecorder()
+
+			handler.DeleteClassHandler(rec, req, classID.Hex(), tt.force)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var response models.GlobalResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Contains(t, response.Message, tt.expectedError)
+			}
+			if tt.expectDelete {
+				mockRepo.AssertCalled(t, "Delete", mock.Anything, classID)
+				mockBookingRepo.AssertNumberOfCalls(t, "Cancel", len(tt.mockBookings))
+				// The waitlist of a deleted class goes with it
+				mockWaitlistRepo.AssertCalled(t, "RemoveWaiting", mock.Anything, classID)
+			} else {
+				mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				mockWaitlistRepo.AssertNotCalled(t, "RemoveWaiting", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGetClassOccurrencesHandler(t *testing.T) {
+	class := &models.Class{
+		ID:              primitive.NewObjectID(),
+		Name:            "Pilates",
+		StartDate:       models.CustomDate(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:         models.CustomDate(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)),
+		Capacity:        10,
+		StartTime:       "07:00",
+		DurationMinutes: 60,
+		Timezone:        "Europe/Dublin",
+		Recurrence:      &models.RecurrenceRule{Days: []string{"mon", "wed", "fri"}},
+	}
+	from := models.CustomDate(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+	to := models.CustomDate(time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name              string
+		from              *models.CustomDate
+		to                *models.CustomDate
+		expectedStatus    int
+		expectedError     string
+		expectedRemaining []int
+	}{
+		{
+			name:              "Sessions of the week with remaining spots",
+			from:              &from,
+			to:                &to,
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: []int{10, 6, 0},
+		},
+		{
+			name:           "Window ending before it starts",
+			from:           &to,
+			to:             &from,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockClassRepository)
+			mockBookingRepo := new(MockBookingRepository)
+			handler := NewClassHandler(service.NewClassService(mockRepo, mockBookingRepo, new(MockWaitlistRepository)))
+
+			mockRepo.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+			mockBookingRepo.On("CountBooked", mock.Anything, class.ID, from, to).Return(map[string]int{"2025-03-05": 4, "2025-03-07": 10}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/classes/"+class.ID.Hex()+"/occurrences", nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetClassOccurrencesHandler(rec, req, class.ID.Hex(), tt.from, tt.to)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
 			if tt.expectedError != "" {
 				var response models.GlobalResponse
 				err := json.Unmarshal(rec.Body.Bytes(), &response)
 				assert.NoError(t, err)
 				assert.Contains(t, response.Message, tt.expectedError)
+				return
+			}
+
+			var response struct {
+				Data []models.Occurrence `json:"data"`
+			}
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			remaining := []int{}
+			for _, o := range response.Data {
+				remaining = append(remaining, o.Remaining)
 			}
+			assert.Equal(t, tt.expectedRemaining, remaining)
 		})
 	}
 }
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/service"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/sinhaseemant/glofox-backend/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemberHandlerInterface defines the contract for MemberHandler
+type MemberHandlerInterface interface {
+	CreateMemberHandler(w http.ResponseWriter, r *http.Request)
+	GetMembersHandler(w http.ResponseWriter, r *http.Request)
+	GetMemberHandler(w http.ResponseWriter, r *http.Request, id string)
+	UpdateMemberHandler(w http.ResponseWriter, r *http.Request, id string)
+	PatchMemberHandler(w http.ResponseWriter, r *http.Request, id string)
+	DeleteMemberHandler(w http.ResponseWriter, r *http.Request, id string)
+}
+
+// MemberHandler struct for dependency injection
+type MemberHandler struct {
+	Service *service.MemberService
+}
+
+// NewMemberHandler initializes a handler with DI
+func NewMemberHandler(svc *service.MemberService) MemberHandlerInterface {
+	return &MemberHandler{Service: svc}
+}
+
+// memberPatch is the PATCH /members/{id} body, only the fields present are applied
+type memberPatch struct {
+	Name   *string `json:"name"`
+	Email  *string `json:"email"`
+	Status *string `json:"status"`
+}
+
+// validateMember returns the validation errors of a member, if any
+func validateMember(member models.Member) []string {
+	var validationErrors []string
+	member.Name = strings.TrimSpace(member.Name)
+	member.Email = strings.TrimSpace(member.Email)
+
+	if member.Name == "" {
+		validationErrors = append(validationErrors, "Member name is required")
+	}
+	if member.Email == "" {
+		validationErrors = append(validationErrors, "Email is required")
+	} else if addr, err := mail.ParseAddress(member.Email); err != nil || addr.Address != member.Email {
+		// ParseAddress also accepts a display name, as in "John Doe <john.doe@example.com>"
+		validationErrors = append(validationErrors, "Email must be a valid email address")
+	}
+	switch member.Status {
+	case "", models.MemberStatusActive, models.MemberStatusInactive:
+	default:
+		validationErrors = append(validationErrors, "Status must be active or inactive")
+	}
+	return validationErrors
+}
+
+// CreateMemberHandler handles member creation
+func (h *MemberHandler) CreateMemberHandler(w http.ResponseWriter, r *http.Request) {
+	var req models.Member
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	if validationErrors := validateMember(req); len(validationErrors) > 0 {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErrors, http.StatusBadRequest, errors.New("Validation failed")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.Service.Create(r.Context(), &req)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+	req.ID = id
+
+	log.Info().Ctx(r.Context()).Msgf("Member created: %+v", req)
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, req, http.StatusCreated, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(resStr)
+}
+
+// GetMembersHandler retrieves all members
+func (h *MemberHandler) GetMembersHandler(w http.ResponseWriter, r *http.Request) {
+	members, err := h.Service.GetAll(r.Context())
+	if err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
+		http.Error(w, string(resStr), http.StatusInternalServerError)
+		return
+	}
+
+	if members == nil {
+		members = []models.Member{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, members, http.StatusOK, nil))
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// GetMemberHandler retrieves a single member
+func (h *MemberHandler) GetMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	memberID, ok := parseMemberID(w, id)
+	if !ok {
+		return
+	}
+
+	member, err := h.Service.Get(r.Context(), memberID)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, member, http.StatusOK, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// UpdateMemberHandler replaces every field of a member
+func (h *MemberHandler) UpdateMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	memberID, ok := parseMemberID(w, id)
+	if !ok {
+		return
+	}
+
+	var req models.Member
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+	req.ID = memberID
+
+	h.updateMember(w, r, &req)
+}
+
+// PatchMemberHandler updates the fields of a member present in the request body
+func (h *MemberHandler) PatchMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	memberID, ok := parseMemberID(w, id)
+	if !ok {
+		return
+	}
+
+	var req memberPatch
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	member, err := h.Service.Get(r.Context(), memberID)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+	if req.Name != nil {
+		member.Name = *req.Name
+	}
+	if req.Email != nil {
+		member.Email = *req.Email
+	}
+	if req.Status != nil {
+		member.Status = *req.Status
+	}
+
+	h.updateMember(w, r, member)
+}
+
+// updateMember validates and stores a full member, shared by PUT and PATCH
+func (h *MemberHandler) updateMember(w http.ResponseWriter, r *http.Request, member *models.Member) {
+	if validationErrors := validateMember(*member); len(validationErrors) > 0 {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, validationErrors, http.StatusBadRequest, errors.New("Validation failed")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.Update(r.Context(), member); err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	log.Info().Ctx(r.Context()).Msgf("Member updated: %+v", *member)
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, member, http.StatusOK, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// DeleteMemberHandler deletes a member
+func (h *MemberHandler) DeleteMemberHandler(w http.ResponseWriter, r *http.Request, id string) {
+	memberID, ok := parseMemberID(w, id)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Delete(r.Context(), memberID); err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	log.Info().Ctx(r.Context()).Msgf("Member deleted: %s", id)
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusSuccess, nil, http.StatusOK, nil))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resStr)
+}
+
+// parseMemberID parses a member ID path parameter, writing a 400 response if it is invalid
+func parseMemberID(w http.ResponseWriter, id string) (primitive.ObjectID, bool) {
+	memberID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, errors.New("Invalid member ID")))
+		http.Error(w, string(resStr), http.StatusBadRequest)
+		return primitive.NilObjectID, false
+	}
+	return memberID, true
+}
+
+// writeMemberError maps errors from the member service to responses
+func writeMemberError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusNotFound, errors.New("Member not found")))
+		http.Error(w, string(resStr), http.StatusNotFound)
+	case errors.Is(err, storage.ErrEmailTaken):
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusConflict, err))
+		http.Error(w, string(resStr), http.StatusConflict)
+	default:
+		resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusInternalServerError, err))
+		http.Error(w, string(resStr), http.StatusInternalServerError)
+	}
+}
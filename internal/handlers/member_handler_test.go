@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/internal/service"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockMemberRepository is a mock implementation of the MemberRepositoryInterface.
+type MockMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockMemberRepository) Create(ctx context.Context, member *models.Member) (primitive.ObjectID, error) {
+	args := m.Called(ctx, member)
+	return args.Get(0).(primitive.ObjectID), args.Error(1)
+}
+
+func (m *MockMemberRepository) GetAll(ctx context.Context) ([]models.Member, error) {
+	args := m.Called(ctx)
+	members, _ := args.Get(0).([]models.Member)
+	return members, args.Error(1)
+}
+
+func (m *MockMemberRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Member, error) {
+	args := m.Called(ctx, id)
+	member, _ := args.Get(0).(*models.Member)
+	return member, args.Error(1)
+}
+
+func (m *MockMemberRepository) Update(ctx context.Context, member *models.Member) error {
+	return m.Called(ctx, member).Error(0)
+}
+
+func (m *MockMemberRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func TestCreateMemberHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    models.Member
+		mockError      error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "Valid member creation",
+			requestBody:    models.Member{Name: "John Doe", Email: " John.Doe@Example.com "},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Missing name and email",
+			requestBody:    models.Member{},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Whitespace-only name",
+			requestBody:    models.Member{Name: "   ", Email: "john.doe@example.com"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Invalid email",
+			requestBody:    models.Member{Name: "John Doe", Email: "john.doe"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Email with a display name",
+			requestBody:    models.Member{Name: "John Doe", Email: "John Doe <john.doe@example.com>"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Invalid status",
+			requestBody:    models.Member{Name: "John Doe", Email: "john.doe@example.com", Status: "banned"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Validation failed",
+		},
+		{
+			name:           "Email already used",
+			requestBody:    models.Member{Name: "John Doe", Email: "john.doe@example.com"},
+			mockError:      storage.ErrEmailTaken,
+			expectedStatus: http.StatusConflict,
+			expectedError:  storage.ErrEmailTaken.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMemberRepository)
+			handler := NewMemberHandler(service.NewMemberService(mockRepo))
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(primitive.NewObjectID(), tt.mockError)
+
+			reqBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/members", bytes.NewBuffer(reqBody))
+			rec := httptest.NewRecorder()
+
+			handler.CreateMemberHandler(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var response models.GlobalResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Contains(t, response.Message, tt.expectedError)
+				return
+			}
+
+			var response struct {
+				Data models.Member `json:"data"`
+			}
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, "john.doe@example.com", response.Data.Email)
+			assert.Equal(t, models.MemberStatusActive, response.Data.Status)
+		})
+	}
+}
+
+func TestGetMembersHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockMembers []models.Member
+	}{
+		{"No members is an empty list", nil},
+		{"Members retrieved successfully", []models.Member{{ID: primitive.NewObjectID(), Name: "John Doe"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMemberRepository)
+			handler := NewMemberHandler(service.NewMemberService(mockRepo))
+			mockRepo.On("GetAll", mock.Anything).Return(tt.mockMembers, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/members", nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetMembersHandler(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response struct {
+				Data []models.Member `json:"data"`
+			}
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.NotNil(t, response.Data, "data must be a list, not null")
+			assert.Len(t, response.Data, len(tt.mockMembers))
+		})
+	}
+}
+
+func TestGetMemberHandler(t *testing.T) {
+	memberID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		id             string
+		mockError      error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "Member retrieved successfully",
+			id:             memberID.Hex(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid member ID",
+			id:             "not-an-id",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid member ID",
+		},
+		{
+			name:           "Member not found",
+			id:             memberID.Hex(),
+			mockError:      storage.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Member not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMemberRepository)
+			handler := NewMemberHandler(service.NewMemberService(mockRepo))
+			mockRepo.On("GetByID", mock.Anything, memberID).Return(&models.Member{ID: memberID, Name: "John Doe"}, tt.mockError)
+
+			req := httptest.NewRequest(http.MethodGet, "/members/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetMemberHandler(rec, req, tt.id)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var response models.GlobalResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Contains(t, response.Message, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestPatchMemberHandler(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	stored := &models.Member{
+		ID:        primitive.NewObjectID(),
+		Name:      "John Doe",
+		Email:     "john.doe@example.com",
+		Status:    models.MemberStatusActive,
+		CreatedAt: createdAt,
+	}
+	mockRepo := new(MockMemberRepository)
+	handler := NewMemberHandler(service.NewMemberService(mockRepo))
+
+	mockRepo.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *models.Member) bool {
+		return m.Name == "John Doe" && m.Status == models.MemberStatusInactive && m.CreatedAt.Equal(createdAt)
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/members/"+stored.ID.Hex(), bytes.NewBufferString(`{"status":"inactive"}`))
+	rec := httptest.NewRecorder()
+
+	handler.PatchMemberHandler(rec, req, stored.ID.Hex())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteMemberHandler(t *testing.T) {
+	memberID := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		mockError      error
+		expectedStatus int
+	}{
+		{"Member deleted successfully", nil, http.StatusOK},
+		{"Member not found", storage.ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMemberRepository)
+			handler := NewMemberHandler(service.NewMemberService(mockRepo))
+			mockRepo.On("Delete", mock.Anything, memberID).Return(tt.mockError)
+
+			req := httptest.NewRequest(http.MethodDelete, "/members/"+memberID.Hex(), nil)
+			rec := httptest.NewRecorder()
+
+			handler.DeleteMemberHandler(rec, req, memberID.Hex())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
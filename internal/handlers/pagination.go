@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sinhaseemant/glofox-backend/internal/service"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+)
+
+// PageQuery holds the limit and cursor query parameters of the list endpoints, both optional
+type PageQuery struct {
+	Limit  *int
+	Cursor string
+}
+
+// page validates the query and translates it to a storage page in the given sort order
+func (q PageQuery) page(sort storage.Sort) (storage.Page, []string) {
+	var validationErrors []string
+	page := storage.Page{Sort: sort, Limit: service.DefaultPageLimit}
+
+	if q.Limit != nil {
+		if *q.Limit < 1 || *q.Limit > service.MaxPageLimit {
+			validationErrors = append(validationErrors, fmt.Sprintf("limit must be between 1 and %d", service.MaxPageLimit))
+		}
+		page.Limit = *q.Limit
+	}
+	if q.Cursor != "" {
+		after, afterKey, err := service.DecodeCursor(q.Cursor, sort)
+		if err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+		page.After, page.AfterKey = after, afterKey
+	}
+	return page, validationErrors
+}
+
+// parseSort parses a sort query parameter, a field name prefixed with "-" for descending order.
+// An empty value is the default _id order.
+func parseSort(value string, fields ...string) (storage.Sort, bool) {
+	if value == "" {
+		return storage.Sort{}, true
+	}
+	sort := storage.Sort{Field: strings.TrimPrefix(value, "-"), Desc: strings.HasPrefix(value, "-")}
+	for _, field := range fields {
+		if sort.Field == field {
+			return sort, true
+		}
+	}
+	return storage.Sort{}, false
+}
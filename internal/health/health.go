@@ -0,0 +1,117 @@
+// Package health answers the liveness and readiness probes of the orchestrator and load balancer
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Probe statuses
+const (
+	StatusOK           = "ok"
+	StatusUnavailable  = "unavailable"
+	StatusShuttingDown = "shutting down"
+)
+
+// Check reports whether a dependency can be used, nil when it can
+type Check func(ctx context.Context) error
+
+// Report is the body of a probe response
+type Report struct {
+	Status       string                `json:"status"`
+	Version      string                `json:"version"`
+	Dependencies map[string]Dependency `json:"dependencies,omitempty"`
+}
+
+// Dependency is the state of one dependency in a readiness report
+type Dependency struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Probes serves /livez and /readyz. The server is live as long as it answers, and ready
+// while every dependency answers its check within Timeout and it is not shutting down.
+type Probes struct {
+	Version string
+	Checks  map[string]Check // By dependency name
+	Timeout time.Duration
+
+	draining atomic.Bool
+}
+
+// NewProbes returns the probes of a build version checking the given dependencies
+func NewProbes(version string, checks map[string]Check) *Probes {
+	return &Probes{Version: version, Checks: checks, Timeout: 2 * time.Second}
+}
+
+// Drain makes the server report itself not ready from now on, so the load balancer
+// stops sending it requests before it shuts down
+func (p *Probes) Drain() {
+	p.draining.Store(true)
+}
+
+// Live answers the liveness probe
+func (p *Probes) Live(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, Report{Status: StatusOK, Version: p.Version})
+}
+
+// Ready answers the readiness probe, checking the dependencies concurrently
+func (p *Probes) Ready(w http.ResponseWriter, r *http.Request) {
+	if p.draining.Load() {
+		writeReport(w, http.StatusServiceUnavailable, Report{Status: StatusShuttingDown, Version: p.Version})
+		return
+	}
+
+	report := Report{Status: StatusOK, Version: p.Version, Dependencies: map[string]Dependency{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range p.Checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			dependency := p.check(r.Context(), check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[name] = dependency
+			if dependency.Status != StatusOK {
+				report.Status = StatusUnavailable
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	writeReport(w, code, report)
+}
+
+// check runs the check of a dependency within Timeout
+func (p *Probes) check(ctx context.Context, check Check) Dependency {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	dependency := Dependency{Status: StatusOK, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		dependency.Status = StatusUnavailable
+		dependency.Error = err.Error()
+	}
+	return dependency
+}
+
+// writeReport writes a report as JSON. Probes are polled, so responses are never cached.
+func writeReport(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// probe calls a probe handler and decodes its report
+func probe(t *testing.T, handler http.HandlerFunc) (int, Report) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var report Report
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+	return rr.Code, report
+}
+
+func TestLive(t *testing.T) {
+	p := NewProbes("1.2.3", map[string]Check{
+		"mongo": func(context.Context) error { return errors.New("unreachable") },
+	})
+
+	// Liveness does not depend on the dependencies
+	code, report := probe(t, p.Live)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, Report{Status: StatusOK, Version: "1.2.3"}, report)
+}
+
+func TestReady(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("connection refused") }
+	hanging := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	t.Run("every dependency answers", func(t *testing.T) {
+		p := NewProbes("1.2.3", map[string]Check{"mongo": ok})
+		code, report := probe(t, p.Ready)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, StatusOK, report.Status)
+		assert.Equal(t, "1.2.3", report.Version)
+		assert.Equal(t, StatusOK, report.Dependencies["mongo"].Status)
+	})
+
+	t.Run("no dependencies", func(t *testing.T) {
+		code, report := probe(t, NewProbes("dev", nil).Ready)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, StatusOK, report.Status)
+	})
+
+	t.Run("a dependency fails", func(t *testing.T) {
+		p := NewProbes("1.2.3", map[string]Check{"mongo": failing, "cache": ok})
+		code, report := probe(t, p.Ready)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, StatusUnavailable, report.Status)
+		assert.Equal(t, Dependency{Status: StatusUnavailable, Error: "connection refused"}, report.Dependencies["mongo"])
+		assert.Equal(t, StatusOK, report.Dependencies["cache"].Status)
+	})
+
+	t.Run("a dependency does not answer in time", func(t *testing.T) {
+		p := NewProbes("1.2.3", map[string]Check{"mongo": hanging})
+		p.Timeout = 10 * time.Millisecond
+		code, report := probe(t, p.Ready)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Dependencies["mongo"].Error)
+	})
+
+	t.Run("shutting down", func(t *testing.T) {
+		p := NewProbes("1.2.3", map[string]Check{"mongo": ok})
+		p.Drain()
+		code, report := probe(t, p.Ready)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, Report{Status: StatusShuttingDown, Version: "1.2.3"}, report)
+
+		// Still alive while draining
+		code, _ = probe(t, p.Live)
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
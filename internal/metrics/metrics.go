@@ -0,0 +1,136 @@
+// Package metrics records the Prometheus metrics of the application: API requests,
+// MongoDB commands and bookings. They are served by Handler.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Sources of a created booking
+const (
+	SourceRequest  = "request"  // Booked by the member
+	SourceWaitlist = "waitlist" // Promoted from the waitlist when a spot was released
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "glofox_http_requests_total",
+		Help: "API requests by OpenAPI operationId and status code.",
+	}, []string{"operation", "status"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "glofox_http_request_duration_seconds",
+		Help:    "Latency of the API requests by OpenAPI operationId and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "status"})
+
+	mongoDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "glofox_mongo_command_duration_seconds",
+		Help: "Latency of the MongoDB commands by command, collection and outcome.",
+		// From half a millisecond, most commands answer in a few
+		Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"command", "collection", "outcome"})
+
+	bookingsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "glofox_bookings_created_total",
+		Help: "Bookings created, by source: request or waitlist.",
+	}, []string{"source"})
+	capacityRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "glofox_booking_capacity_rejections_total",
+		Help: "Booking requests turned down because the class was full, by outcome: rejected or waitlisted.",
+	}, []string{"outcome"})
+)
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware records the count and latency of the requests to an API operation. It is
+// meant for api.ChiServerOptions.Middlewares, operation names the operation of a request.
+func Middleware(operation func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK // Nothing written
+			}
+			labels := prometheus.Labels{"operation": operation(r), "status": strconv.Itoa(status)}
+			httpRequests.With(labels).Inc()
+			httpDuration.With(labels).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// ErrorHandler wraps handle, the api.ChiServerOptions.ErrorHandlerFunc answering the requests
+// whose parameters cannot be read. Those are answered before the middlewares run, so it
+// records them as Middleware does.
+func ErrorHandler(operation func(r *http.Request) string, handle func(w http.ResponseWriter, r *http.Request, err error)) func(w http.ResponseWriter, r *http.Request, err error) {
+	record := Middleware(operation)
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		record(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handle(w, r, err)
+		})).ServeHTTP(w, r)
+	}
+}
+
+// MongoMonitor returns a command monitor recording the latency of the MongoDB commands
+func MongoMonitor() *event.CommandMonitor {
+	var collections sync.Map // By request ID of the commands in flight
+	observe := func(e event.CommandFinishedEvent, outcome string) {
+		collection, _ := collections.LoadAndDelete(e.RequestID)
+		name, _ := collection.(string)
+		mongoDuration.WithLabelValues(e.CommandName, name, outcome).Observe(e.Duration.Seconds())
+	}
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			collections.Store(e.RequestID, CommandCollection(e.CommandName, e.Command))
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			observe(e.CommandFinishedEvent, "ok")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			observe(e.CommandFinishedEvent, "error")
+		},
+	}
+}
+
+// CommandCollection returns the collection a MongoDB command runs on, "" for commands on
+// no collection such as ping or commitTransaction
+func CommandCollection(name string, command bson.Raw) string {
+	if name == "getMore" {
+		collection, _ := command.Lookup("collection").StringValueOK()
+		return collection
+	}
+	// The other commands name their collection as the value of the command
+	collection, _ := command.Lookup(name).StringValueOK()
+	return collection
+}
+
+// BookingCreated counts a booking created from source
+func BookingCreated(source string) {
+	bookingsCreated.WithLabelValues(source).Inc()
+}
+
+// CapacityRejected counts a booking request for a full class, which was waitlisted or rejected
+func CapacityRejected(waitlisted bool) {
+	outcome := "rejected"
+	if waitlisted {
+		outcome = "waitlisted"
+	}
+	capacityRejections.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestMiddleware(t *testing.T) {
+	handler := Middleware(func(*http.Request) string { return "BookClass" })(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("full") != "" {
+				http.Error(w, "Class is full", http.StatusConflict)
+				return
+			}
+			w.Write([]byte("{}"))
+		}))
+
+	booked := testutil.ToFloat64(httpRequests.WithLabelValues("BookClass", "200"))
+	conflicts := testutil.ToFloat64(httpRequests.WithLabelValues("BookClass", "409"))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/bookings", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/bookings?full=1", nil))
+
+	assert.Equal(t, booked+1, testutil.ToFloat64(httpRequests.WithLabelValues("BookClass", "200")))
+	assert.Equal(t, conflicts+1, testutil.ToFloat64(httpRequests.WithLabelValues("BookClass", "409")))
+	latencies, err := testutil.CollectAndFormat(httpDuration, expfmt.TypeTextPlain, "glofox_http_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Contains(t, string(latencies), `glofox_http_request_duration_seconds_count{operation="BookClass",status="409"} 1`)
+}
+
+func TestErrorHandler(t *testing.T) {
+	handle := ErrorHandler(func(*http.Request) string { return "GetClasses" },
+		func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		})
+
+	invalid := testutil.ToFloat64(httpRequests.WithLabelValues("GetClasses", "400"))
+	rr := httptest.NewRecorder()
+	handle(rr, httptest.NewRequest(http.MethodGet, "/classes?limit=abc", nil), errors.New("invalid limit"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, invalid+1, testutil.ToFloat64(httpRequests.WithLabelValues("GetClasses", "400")))
+}
+
+func TestMongoMonitor(t *testing.T) {
+	monitor := MongoMonitor()
+	ctx := context.Background()
+	command, _ := bson.Marshal(bson.D{{Key: "find", Value: "classes"}, {Key: "filter", Value: bson.D{}}})
+
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, CommandName: "find", RequestID: 1})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName: "find", RequestID: 1, Duration: 3 * time.Millisecond,
+	}})
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, CommandName: "find", RequestID: 2})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName: "find", RequestID: 2, Duration: time.Second,
+	}})
+
+	got, err := testutil.CollectAndFormat(mongoDuration, expfmt.TypeTextPlain, "glofox_mongo_command_duration_seconds")
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), `glofox_mongo_command_duration_seconds_count{collection="classes",command="find",outcome="ok"} 1`)
+	assert.Contains(t, string(got), `glofox_mongo_command_duration_seconds_count{collection="classes",command="find",outcome="error"} 1`)
+}
+
+func TestCommandCollection(t *testing.T) {
+	raw := func(d bson.D) bson.Raw {
+		b, _ := bson.Marshal(d)
+		return b
+	}
+	assert.Equal(t, "bookings", CommandCollection("insert", raw(bson.D{{Key: "insert", Value: "bookings"}})))
+	assert.Equal(t, "classes", CommandCollection("getMore", raw(bson.D{{Key: "getMore", Value: int64(42)}, {Key: "collection", Value: "classes"}})))
+	assert.Equal(t, "", CommandCollection("ping", raw(bson.D{{Key: "ping", Value: 1}})))
+}
+
+func TestBookingCounters(t *testing.T) {
+	created := testutil.ToFloat64(bookingsCreated.WithLabelValues(SourceWaitlist))
+	waitlisted := testutil.ToFloat64(capacityRejections.WithLabelValues("waitlisted"))
+	rejected := testutil.ToFloat64(capacityRejections.WithLabelValues("rejected"))
+
+	BookingCreated(SourceWaitlist)
+	CapacityRejected(true)
+	CapacityRejected(false)
+	CapacityRejected(false)
+
+	assert.Equal(t, created+1, testutil.ToFloat64(bookingsCreated.WithLabelValues(SourceWaitlist)))
+	assert.Equal(t, waitlisted+1, testutil.ToFloat64(capacityRejections.WithLabelValues("waitlisted")))
+	assert.Equal(t, rejected+2, testutil.ToFloat64(capacityRejections.WithLabelValues("rejected")))
+}
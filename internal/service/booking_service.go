@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/metrics"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BookingResult is the outcome of a booking request: either a confirmed booking or,
+// when the occurrence is full and the member opted in, a waitlist entry.
+type BookingResult struct {
+	Booking  *models.Booking
+	Waitlist *models.WaitlistEntry
+}
+
+// BookingService holds the booking, cancellation and waitlist promotion rules
+type BookingService struct {
+	Bookings storage.BookingRepositoryInterface
+	Classes  storage.ClassRepositoryInterface
+	Members  storage.MemberRepositoryInterface
+	Waitlist storage.WaitlistRepositoryInterface
+}
+
+// NewBookingService initializes a BookingService with DI
+func NewBookingService(bookings storage.BookingRepositoryInterface, classes storage.ClassRepositoryInterface, members storage.MemberRepositoryInterface, waitlist storage.WaitlistRepositoryInterface) *BookingService {
+	return &BookingService{Bookings: bookings, Classes: classes, Members: members, Waitlist: waitlist}
+}
+
+// Book validates the booking against its class and reserves a spot. If the occurrence
+// is full and joinWaitlist is set the member is queued instead of being rejected.
+func (s *BookingService) Book(ctx context.Context, booking *models.Booking, joinWaitlist bool) (*BookingResult, error) {
+	class, err := s.Classes.GetByID(ctx, booking.ClassID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.StartsAt != nil {
+		date, ok := class.SessionDate(*booking.StartsAt)
+		if !ok || (!booking.Date.IsZero() && date.String() != booking.Date.String()) {
+			return nil, &ValidationError{Errors: []string{
+				fmt.Sprintf("No session of the class starts at %s", booking.StartsAt.Format(time.RFC3339)),
+			}}
+		}
+		booking.Date = date
+	}
+	if !class.IsActiveOn(booking.Date) {
+		return nil, &ValidationError{Errors: []string{
+			fmt.Sprintf("Booking date must be between %s and %s", class.StartDate, class.EndDate),
+		}}
+	}
+	if !class.OccursOn(booking.Date) {
+		return nil, &ValidationError{Errors: []string{
+			fmt.Sprintf("Class does not run on %s (%s)", booking.Date, booking.Date.Weekday()),
+		}}
+	}
+	member, err := s.Members.GetByID(ctx, booking.MemberID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !member.IsActive() {
+		return nil, &ValidationError{Errors: []string{"Member is not active"}}
+	}
+
+	// The class and member names always come from the stored documents, never from the client
+	booking.ClassName = class.Name
+	booking.MemberName = member.Name
+	booking.StartsAt = sessionStart(class, booking.Date)
+	booking.Status = models.BookingStatusActive
+	booking.CancelledAt = nil
+	booking.CancellationReason = ""
+
+	id, err := s.Bookings.Create(ctx, booking, class.Capacity)
+	if errors.Is(err, storage.ErrClassFull) && !joinWaitlist {
+		metrics.CapacityRejected(false)
+	}
+	if errors.Is(err, storage.ErrClassFull) && joinWaitlist {
+		entry := &models.WaitlistEntry{
+			ClassID:    booking.ClassID,
+			Date:       booking.Date,
+			MemberID:   booking.MemberID,
+			MemberName: booking.MemberName,
+			Status:     models.WaitlistStatusWaiting,
+			CreatedAt:  time.Now().UTC(),
+		}
+		entryID, position, err := s.Waitlist.Add(ctx, entry)
+		// Only a member actually queued counts as waitlisted
+		metrics.CapacityRejected(err == nil)
+		if err != nil {
+			return nil, err
+		}
+		entry.ID = entryID
+		entry.Position = position
+		return &BookingResult{Waitlist: entry}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	booking.ID = id
+	metrics.BookingCreated(metrics.SourceRequest)
+	return &BookingResult{Booking: booking}, nil
+}
+
+// sessionStart returns the start of the class session on the date, or nil for classes without a start time
+func sessionStart(class *models.Class, date models.CustomDate) *time.Time {
+	start, _, ok := class.SessionTimes(date)
+	if !ok {
+		return nil
+	}
+	start = start.UTC()
+	return &start
+}
+
+// GetAll returns a page of the bookings matching the filter, cancelled ones included unless filtered
+// by status, and the cursor of the next page or "" on the last one
+func (s *BookingService) GetAll(ctx context.Context, filter storage.BookingFilter, page storage.Page) ([]models.Booking, string, error) {
+	bookings, err := s.Bookings.GetAll(ctx, filter, lookahead(page))
+	if err != nil {
+		return nil, "", err
+	}
+	return trimPage(bookings, page)
+}
+
+// Cancel cancels a booking and hands the released spot to the first waitlisted member.
+// A failed promotion is logged but does not fail the cancellation.
+func (s *BookingService) Cancel(ctx context.Context, id primitive.ObjectID, reason string) (*models.Booking, error) {
+	booking, err := s.Bookings.Cancel(ctx, id, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	promoted, err := s.PromoteNext(ctx, booking.ClassID, booking.Date)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Failed to promote waitlist for class %s on %s", booking.ClassID.Hex(), booking.Date)
+	} else if promoted != nil {
+		log.Info().Ctx(ctx).Msgf("Promoted waitlisted booking: %v", promoted)
+	}
+	return booking, nil
+}
+
+// PromoteNext turns the first waitlisted member of an occurrence into a confirmed booking.
+// It returns a nil booking when nobody is waiting or the freed spot was taken meanwhile,
+// in which case the entry keeps its place in the queue. Members who already got a spot
+// on their own are passed over, their entry stays marked promoted without a booking.
+func (s *BookingService) PromoteNext(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) (*models.Booking, error) {
+	class, err := s.Classes.GetByID(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		entry, err := s.Waitlist.PopNext(ctx, classID, date)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		booking := &models.Booking{
+			ClassID:    classID,
+			ClassName:  class.Name,
+			MemberID:   entry.MemberID,
+			MemberName: entry.MemberName,
+			Date:       entry.Date,
+			StartsAt:   sessionStart(class, entry.Date),
+			Status:     models.BookingStatusActive,
+		}
+		id, err := s.Bookings.Create(ctx, booking, class.Capacity)
+		if errors.Is(err, storage.ErrAlreadyBooked) {
+			log.Info().Ctx(ctx).Msgf("Waitlisted member %s is already booked, offering the spot to the next member", entry.MemberID.Hex())
+			continue
+		}
+		if err != nil {
+			if requeueErr := s.Waitlist.Requeue(ctx, entry.ID); requeueErr != nil {
+				log.Error().Ctx(ctx).Err(requeueErr).Msgf("Failed to requeue waitlist entry %s", entry.ID.Hex())
+			}
+			if errors.Is(err, storage.ErrClassFull) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		booking.ID = id
+		metrics.BookingCreated(metrics.SourceWaitlist)
+
+		if err := s.Waitlist.MarkPromoted(ctx, entry.ID, id); err != nil {
+			log.Error().Ctx(ctx).Err(err).Msgf("Failed to link waitlist entry %s to booking %s", entry.ID.Hex(), id.Hex())
+		}
+		return booking, nil
+	}
+}
+
+// GetWaitlist returns the queue for a class occurrence, or storage.ErrNotFound for an unknown class
+func (s *BookingService) GetWaitlist(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) ([]models.WaitlistEntry, error) {
+	if _, err := s.Classes.GetByID(ctx, classID); err != nil {
+		return nil, err
+	}
+	return s.Waitlist.List(ctx, classID, date)
+}
@@ -0,0 +1,485 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockBookingRepository is a mock implementation of the BookingRepositoryInterface.
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) Create(ctx context.Context, booking *models.Booking, capacity int) (primitive.ObjectID, error) {
+	args := m.Called(ctx, booking, capacity)
+	return args.Get(0).(primitive.ObjectID), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetAll(ctx context.Context, filter storage.BookingFilter, page storage.Page) ([]models.Booking, error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]models.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Booking, error) {
+	args := m.Called(ctx, id)
+	booking, _ := args.Get(0).(*models.Booking)
+	return booking, args.Error(1)
+}
+
+func (m *MockBookingRepository) Cancel(ctx context.Context, id primitive.ObjectID, reason string) (*models.Booking, error) {
+	args := m.Called(ctx, id, reason)
+	booking, _ := args.Get(0).(*models.Booking)
+	return booking, args.Error(1)
+}
+
+func (m *MockBookingRepository) CountBooked(ctx context.Context, classID primitive.ObjectID, from, to models.CustomDate) (map[string]int, error) {
+	args := m.Called(ctx, classID, from, to)
+	booked, _ := args.Get(0).(map[string]int)
+	return booked, args.Error(1)
+}
+
+func (m *MockBookingRepository) GetActiveByClass(ctx context.Context, classID primitive.ObjectID, from models.CustomDate) ([]models.Booking, error) {
+	args := m.Called(ctx, classID, from)
+	bookings, _ := args.Get(0).([]models.Booking)
+	return bookings, args.Error(1)
+}
+
+// MockClassRepository is a mock implementation of the ClassRepositoryInterface.
+type MockClassRepository struct {
+	mock.Mock
+}
+
+func (m *MockClassRepository) Create(ctx context.Context, class *models.Class) (primitive.ObjectID, error) {
+	args := m.Called(ctx, class)
+	return args.Get(0).(primitive.ObjectID), args.Error(1)
+}
+
+func (m *MockClassRepository) GetAll(ctx context.Context, filter storage.ClassFilter, page storage.Page) ([]models.Class, error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]models.Class), args.Error(1)
+}
+
+func (m *MockClassRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Class, error) {
+	args := m.Called(ctx, id)
+	class, _ := args.Get(0).(*models.Class)
+	return class, args.Error(1)
+}
+
+func (m *MockClassRepository) Update(ctx context.Context, class *models.Class) error {
+	return m.Called(ctx, class).Error(0)
+}
+
+func (m *MockClassRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+// MockWaitlistRepository is a mock implementation of the WaitlistRepositoryInterface.
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) Add(ctx context.Context, entry *models.WaitlistEntry) (primitive.ObjectID, int, error) {
+	args := m.Called(ctx, entry)
+	return args.Get(0).(primitive.ObjectID), args.Int(1), args.Error(2)
+}
+
+func (m *MockWaitlistRepository) List(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) ([]models.WaitlistEntry, error) {
+	args := m.Called(ctx, classID, date)
+	entries, _ := args.Get(0).([]models.WaitlistEntry)
+	return entries, args.Error(1)
+}
+
+func (m *MockWaitlistRepository) PopNext(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) (*models.WaitlistEntry, error) {
+	args := m.Called(ctx, classID, date)
+	entry, _ := args.Get(0).(*models.WaitlistEntry)
+	return entry, args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Requeue(ctx context.Context, id primitive.ObjectID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockWaitlistRepository) MarkPromoted(ctx context.Context, id primitive.ObjectID, bookingID primitive.ObjectID) error {
+	return m.Called(ctx, id, bookingID).Error(0)
+}
+
+func (m *MockWaitlistRepository) RemoveWaiting(ctx context.Context, classID primitive.ObjectID) (int, error) {
+	args := m.Called(ctx, classID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockMemberRepository is a mock implementation of the MemberRepositoryInterface.
+type MockMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockMemberRepository) Create(ctx context.Context, member *models.Member) (primitive.ObjectID, error) {
+	args := m.Called(ctx, member)
+	return args.Get(0).(primitive.ObjectID), args.Error(1)
+}
+
+func (m *MockMemberRepository) GetAll(ctx context.Context) ([]models.Member, error) {
+	args := m.Called(ctx)
+	members, _ := args.Get(0).([]models.Member)
+	return members, args.Error(1)
+}
+
+func (m *MockMemberRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Member, error) {
+	args := m.Called(ctx, id)
+	member, _ := args.Get(0).(*models.Member)
+	return member, args.Error(1)
+}
+
+func (m *MockMemberRepository) Update(ctx context.Context, member *models.Member) error {
+	return m.Called(ctx, member).Error(0)
+}
+
+func (m *MockMemberRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+// testMember is the active member returned by newTestMembers
+var testMember = &models.Member{ID: primitive.NewObjectID(), Name: "John Doe", Email: "john.doe@example.com", Status: models.MemberStatusActive}
+
+// newTestMembers returns a member repository in which testMember exists
+func newTestMembers() *MockMemberRepository {
+	members := new(MockMemberRepository)
+	members.On("GetByID", mock.Anything, testMember.ID).Return(testMember, nil)
+	return members
+}
+
+func newTestClass() *models.Class {
+	return &models.Class{
+		ID:        primitive.NewObjectID(),
+		Name:      "Yoga Class",
+		StartDate: models.CustomDate(time.Now()),
+		EndDate:   models.CustomDate(time.Now().AddDate(0, 0, 7)),
+		Capacity:  1,
+	}
+}
+
+// capacityRejections reads the glofox_booking_capacity_rejections_total counter of an outcome
+func capacityRejections(t *testing.T, outcome string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "glofox_booking_capacity_rejections_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "outcome" && label.GetValue() == outcome {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestBook(t *testing.T) {
+	class := newTestClass()
+	date := models.CustomDate(time.Now())
+
+	t.Run("should join the waitlist when the class is full and the member opted in", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		entryID := primitive.NewObjectID()
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		bookings.On("Create", mock.Anything, mock.Anything, class.Capacity).Return(primitive.NilObjectID, storage.ErrClassFull)
+		waitlist.On("Add", mock.Anything, mock.MatchedBy(func(e *models.WaitlistEntry) bool {
+			return e.MemberID == testMember.ID && e.MemberName == "John Doe" && e.ClassID == class.ID && e.Status == models.WaitlistStatusWaiting
+		})).Return(entryID, 2, nil)
+
+		result, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, Date: date}, true)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Booking)
+		assert.Equal(t, entryID, result.Waitlist.ID)
+		assert.Equal(t, 2, result.Waitlist.Position)
+	})
+
+	t.Run("should not count a member already on the waitlist as waitlisted", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		waitlisted, rejected := capacityRejections(t, "waitlisted"), capacityRejections(t, "rejected")
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		bookings.On("Create", mock.Anything, mock.Anything, class.Capacity).Return(primitive.NilObjectID, storage.ErrClassFull)
+		waitlist.On("Add", mock.Anything, mock.Anything).Return(primitive.NilObjectID, 0, storage.ErrAlreadyWaitlisted)
+
+		_, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, Date: date}, true)
+
+		assert.ErrorIs(t, err, storage.ErrAlreadyWaitlisted)
+		assert.Equal(t, waitlisted, capacityRejections(t, "waitlisted"))
+		assert.Equal(t, rejected+1, capacityRejections(t, "rejected"))
+	})
+
+	t.Run("should reject a date the recurring class does not run on", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		recurring := *class
+		recurring.Recurrence = &models.RecurrenceRule{Days: []string{date.AddDays(1).Weekday().String()[:3]}}
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(&recurring, nil)
+
+		_, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, Date: date}, false)
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Errors[0], "Class does not run on")
+		bookings.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should record the session start for classes with a start time", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		timed := *class
+		timed.StartTime, timed.DurationMinutes, timed.Timezone = "18:30", 60, "Europe/Dublin"
+		startsAt, _, _ := timed.SessionTimes(date)
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(&timed, nil)
+		bookings.On("Create", mock.Anything, mock.MatchedBy(func(b *models.Booking) bool {
+			return b.StartsAt != nil && b.StartsAt.Equal(startsAt)
+		}), class.Capacity).Return(primitive.NewObjectID(), nil)
+
+		result, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, Date: date}, false)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Booking.StartsAt.Equal(startsAt))
+	})
+
+	t.Run("should derive the date from the session start", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		timed := *class
+		timed.StartTime, timed.DurationMinutes, timed.Timezone = "23:30", 60, "America/New_York"
+		startsAt, _, _ := timed.SessionTimes(date)
+		startsAt = startsAt.UTC()
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(&timed, nil)
+		bookings.On("Create", mock.Anything, mock.Anything, class.Capacity).Return(primitive.NewObjectID(), nil)
+
+		result, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, StartsAt: &startsAt}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, date.String(), result.Booking.Date.String())
+	})
+
+	t.Run("should reject an instant that is not a session start", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		timed := *class
+		timed.StartTime, timed.DurationMinutes, timed.Timezone = "18:30", 60, "Europe/Dublin"
+		startsAt, _, _ := timed.SessionTimes(date)
+		startsAt = startsAt.Add(time.Hour)
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(&timed, nil)
+
+		_, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, StartsAt: &startsAt}, false)
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Errors[0], "No session of the class starts at")
+		bookings.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a full class when the member did not opt in", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		bookings.On("Create", mock.Anything, mock.Anything, class.Capacity).Return(primitive.NilObjectID, storage.ErrClassFull)
+
+		result, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, Date: date}, false)
+
+		assert.ErrorIs(t, err, storage.ErrClassFull)
+		assert.Nil(t, result)
+		waitlist.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an unknown member", func(t *testing.T) {
+		bookings, classes, members, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockMemberRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, members, waitlist)
+		unknown := primitive.NewObjectID()
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		members.On("GetByID", mock.Anything, unknown).Return(nil, storage.ErrNotFound)
+
+		_, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: unknown, Date: date}, false)
+
+		assert.ErrorIs(t, err, ErrMemberNotFound)
+		bookings.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an inactive member", func(t *testing.T) {
+		bookings, classes, members, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockMemberRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, members, waitlist)
+		inactive := &models.Member{ID: primitive.NewObjectID(), Name: "Jane Doe", Status: models.MemberStatusInactive}
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		members.On("GetByID", mock.Anything, inactive.ID).Return(inactive, nil)
+
+		_, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: inactive.ID, Date: date}, false)
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{"Member is not active"}, validationErr.Errors)
+		bookings.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should take the member name from the stored member", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		bookings.On("Create", mock.Anything, mock.Anything, class.Capacity).Return(primitive.NewObjectID(), nil)
+
+		result, err := svc.Book(context.Background(), &models.Booking{ClassID: class.ID, MemberID: testMember.ID, MemberName: "Spoofed", Date: date}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testMember.Name, result.Booking.MemberName)
+	})
+}
+
+func TestCancel(t *testing.T) {
+	class := newTestClass()
+	date := models.CustomDate(time.Now())
+	cancelled := &models.Booking{ID: primitive.NewObjectID(), ClassID: class.ID, Date: date, Status: models.BookingStatusCancelled}
+
+	t.Run("should promote the first waitlisted member into the released spot", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		entry := &models.WaitlistEntry{ID: primitive.NewObjectID(), ClassID: class.ID, Date: date, MemberID: primitive.NewObjectID(), MemberName: "Jane Doe"}
+		promotedID := primitive.NewObjectID()
+
+		bookings.On("Cancel", mock.Anything, cancelled.ID, "").Return(cancelled, nil)
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		waitlist.On("PopNext", mock.Anything, class.ID, date).Return(entry, nil)
+		bookings.On("Create", mock.Anything, mock.MatchedBy(func(b *models.Booking) bool {
+			return b.MemberID == entry.MemberID && b.MemberName == "Jane Doe" && b.ClassName == class.Name && b.Status == models.BookingStatusActive
+		}), class.Capacity).Return(promotedID, nil)
+		waitlist.On("MarkPromoted", mock.Anything, entry.ID, promotedID).Return(nil)
+
+		booking, err := svc.Cancel(context.Background(), cancelled.ID, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, cancelled, booking)
+		waitlist.AssertCalled(t, "MarkPromoted", mock.Anything, entry.ID, promotedID)
+	})
+
+	t.Run("should not promote anyone when the waitlist is empty", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+
+		bookings.On("Cancel", mock.Anything, cancelled.ID, "").Return(cancelled, nil)
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		waitlist.On("PopNext", mock.Anything, class.ID, date).Return(nil, storage.ErrNotFound)
+
+		_, err := svc.Cancel(context.Background(), cancelled.ID, "")
+
+		assert.NoError(t, err)
+		bookings.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should still cancel when promotion fails", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+
+		bookings.On("Cancel", mock.Anything, cancelled.ID, "").Return(cancelled, nil)
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		waitlist.On("PopNext", mock.Anything, class.ID, date).Return(nil, errors.New("connection reset"))
+
+		booking, err := svc.Cancel(context.Background(), cancelled.ID, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, cancelled, booking)
+	})
+
+	t.Run("should not promote when the cancellation fails", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+
+		bookings.On("Cancel", mock.Anything, cancelled.ID, "").Return(nil, storage.ErrAlreadyCancelled)
+
+		_, err := svc.Cancel(context.Background(), cancelled.ID, "")
+
+		assert.ErrorIs(t, err, storage.ErrAlreadyCancelled)
+		waitlist.AssertNotCalled(t, "PopNext", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPromoteNext(t *testing.T) {
+	class := newTestClass()
+	date := models.CustomDate(time.Now())
+
+	t.Run("should requeue the entry when the spot was taken meanwhile", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		entry := &models.WaitlistEntry{ID: primitive.NewObjectID(), ClassID: class.ID, Date: date, MemberName: "Jane Doe"}
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		waitlist.On("PopNext", mock.Anything, class.ID, date).Return(entry, nil)
+		bookings.On("Create", mock.Anything, mock.Anything, class.Capacity).Return(primitive.NilObjectID, storage.ErrClassFull)
+		waitlist.On("Requeue", mock.Anything, entry.ID).Return(nil)
+
+		booking, err := svc.PromoteNext(context.Background(), class.ID, date)
+
+		assert.NoError(t, err)
+		assert.Nil(t, booking)
+		waitlist.AssertCalled(t, "Requeue", mock.Anything, entry.ID)
+		waitlist.AssertNotCalled(t, "MarkPromoted", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should pass over members who are already booked", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		booked := &models.WaitlistEntry{ID: primitive.NewObjectID(), ClassID: class.ID, Date: date, MemberID: primitive.NewObjectID()}
+		next := &models.WaitlistEntry{ID: primitive.NewObjectID(), ClassID: class.ID, Date: date, MemberID: primitive.NewObjectID()}
+		promotedID := primitive.NewObjectID()
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		waitlist.On("PopNext", mock.Anything, class.ID, date).Return(booked, nil).Once()
+		waitlist.On("PopNext", mock.Anything, class.ID, date).Return(next, nil).Once()
+		bookings.On("Create", mock.Anything, mock.MatchedBy(func(b *models.Booking) bool {
+			return b.MemberID == booked.MemberID
+		}), class.Capacity).Return(primitive.NilObjectID, storage.ErrAlreadyBooked)
+		bookings.On("Create", mock.Anything, mock.MatchedBy(func(b *models.Booking) bool {
+			return b.MemberID == next.MemberID
+		}), class.Capacity).Return(promotedID, nil)
+		waitlist.On("MarkPromoted", mock.Anything, next.ID, promotedID).Return(nil)
+
+		booking, err := svc.PromoteNext(context.Background(), class.ID, date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, next.MemberID, booking.MemberID)
+		waitlist.AssertNotCalled(t, "Requeue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should requeue and report unexpected booking errors", func(t *testing.T) {
+		bookings, classes, waitlist := new(MockBookingRepository), new(MockClassRepository), new(MockWaitlistRepository)
+		svc := NewBookingService(bookings, classes, newTestMembers(), waitlist)
+		entry := &models.WaitlistEntry{ID: primitive.NewObjectID(), ClassID: class.ID, Date: date, MemberName: "Jane Doe"}
+
+		classes.On("GetByID", mock.Anything, class.ID).Return(class, nil)
+		waitlist.On("PopNext", mock.Anything, class.ID, date).Return(entry, nil)
+		bookings.On("Create", mock.Anything, mock.Anything, class.Capacity).Return(primitive.NilObjectID, errors.New("write failed"))
+		waitlist.On("Requeue", mock.Anything, entry.ID).Return(nil)
+
+		booking, err := svc.PromoteNext(context.Background(), class.ID, date)
+
+		assert.Error(t, err)
+		assert.Nil(t, booking)
+		waitlist.AssertCalled(t, "Requeue", mock.Anything, entry.ID)
+	})
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Occurrence listing windows, in days
+const (
+	defaultOccurrenceWindowDays = 28
+	maxOccurrenceWindowDays     = 366
+)
+
+// Cancellation reasons recorded on bookings cancelled by a forced class change
+const (
+	reasonCapacityReduced = "Class capacity was reduced"
+	reasonDateRemoved     = "Class no longer runs on this date"
+	reasonClassDeleted    = "Class was deleted"
+)
+
+// ClassService holds the rules for changing classes that already have bookings
+type ClassService struct {
+	Classes  storage.ClassRepositoryInterface
+	Bookings storage.BookingRepositoryInterface
+	Waitlist storage.WaitlistRepositoryInterface
+}
+
+// NewClassService initializes a ClassService with DI
+func NewClassService(classes storage.ClassRepositoryInterface, bookings storage.BookingRepositoryInterface, waitlist storage.WaitlistRepositoryInterface) *ClassService {
+	return &ClassService{Classes: classes, Bookings: bookings, Waitlist: waitlist}
+}
+
+// Create stores a new class and returns its ID
+func (s *ClassService) Create(ctx context.Context, class *models.Class) (primitive.ObjectID, error) {
+	return s.Classes.Create(ctx, class)
+}
+
+// ClassSearch narrows down the classes returned by GetAll. Zero fields are ignored.
+type ClassSearch struct {
+	storage.ClassFilter
+	// MinSpots keeps the classes with a session between From and To that has this many spots
+	// left. It needs both From and To.
+	MinSpots int
+}
+
+// GetAll returns a page of the classes matching the search and the cursor of the next page,
+// or "" on the last one. When the search has both From and To, only the classes with a session
+// in that window are returned. The window is limited to maxOccurrenceWindowDays.
+func (s *ClassService) GetAll(ctx context.Context, search ClassSearch, page storage.Page) ([]models.Class, string, error) {
+	if search.From == nil || search.To == nil {
+		classes, err := s.Classes.GetAll(ctx, search.ClassFilter, lookahead(page))
+		if err != nil {
+			return nil, "", err
+		}
+		return trimPage(classes, page)
+	}
+	if search.To.String() > search.From.AddDays(maxOccurrenceWindowDays-1).String() {
+		return nil, "", &ValidationError{Errors: []string{fmt.Sprintf("The window from from to to must not exceed %d days", maxOccurrenceWindowDays)}}
+	}
+
+	// Sessions and spots are not stored on classes, so candidates are fetched batch by batch
+	// until the page is full or the classes run out
+	batch := lookahead(page)
+	var matches []models.Class
+	for {
+		classes, err := s.Classes.GetAll(ctx, search.ClassFilter, batch)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, class := range classes {
+			ok, err := s.hasSession(ctx, &class, search)
+			if err != nil {
+				return nil, "", err
+			}
+			if ok {
+				matches = append(matches, class)
+			}
+		}
+		if batch.Limit == 0 || len(classes) < batch.Limit || len(matches) > page.Limit {
+			break
+		}
+		if batch, err = advance(batch, classes[len(classes)-1]); err != nil {
+			return nil, "", err
+		}
+	}
+	return trimPage(matches, page)
+}
+
+// hasSession reports whether the class has a session between the search From and To with at least MinSpots spots left
+func (s *ClassService) hasSession(ctx context.Context, class *models.Class, search ClassSearch) (bool, error) {
+	if search.MinSpots <= 0 {
+		return len(class.Occurrences(*search.From, *search.To)) > 0, nil
+	}
+	occurrences, err := s.withRemaining(ctx, class, *search.From, *search.To)
+	if err != nil {
+		return false, err
+	}
+	for _, o := range occurrences {
+		if o.Remaining >= search.MinSpots {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Get returns a class, or storage.ErrNotFound
+func (s *ClassService) Get(ctx context.Context, id primitive.ObjectID) (*models.Class, error) {
+	return s.Classes.GetByID(ctx, id)
+}
+
+// Update replaces a class. Upcoming bookings that no longer fit the class, because their date
+// is no longer an occurrence or their date is over the new capacity, make the update fail with
+// an AffectedBookingsError unless force is set, in which case they are cancelled. Past bookings
+// are history and left alone.
+func (s *ClassService) Update(ctx context.Context, class *models.Class, force bool) ([]models.Booking, error) {
+	if _, err := s.Classes.GetByID(ctx, class.ID); err != nil {
+		return nil, err
+	}
+
+	today := models.CustomDate(time.Now()).UTC()
+	bookings, err := s.Bookings.GetActiveByClass(ctx, class.ID, today)
+	if err != nil {
+		return nil, err
+	}
+	affected := affectedBookings(*class, bookings, today)
+	if len(affected) > 0 && !force {
+		return nil, &AffectedBookingsError{Bookings: affected}
+	}
+
+	// Store the class first so new bookings already see the reduced capacity or range
+	if err := s.Classes.Update(ctx, class); err != nil {
+		return nil, err
+	}
+	return s.cancelAll(ctx, affected, func(b models.Booking) string {
+		if !class.OccursOn(b.Date) {
+			return reasonDateRemoved
+		}
+		return reasonCapacityReduced
+	})
+}
+
+// Delete removes a class and its waitlist. A class with upcoming active bookings is only
+// deleted when force is set, and those bookings are cancelled. Past bookings are left alone.
+func (s *ClassService) Delete(ctx context.Context, id primitive.ObjectID, force bool) ([]models.Booking, error) {
+	if _, err := s.Classes.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.Bookings.GetActiveByClass(ctx, id, models.CustomDate(time.Now()).UTC())
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) > 0 && !force {
+		return nil, &AffectedBookingsError{Bookings: bookings}
+	}
+
+	if err := s.Classes.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	removed, err := s.Waitlist.RemoveWaiting(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Ctx(ctx).Msgf("Removed %d waitlist entries of deleted class %s", removed, id.Hex())
+	return s.cancelAll(ctx, bookings, func(models.Booking) string {
+		return reasonClassDeleted
+	})
+}
+
+// Occurrences expands a class into its sessions between from and to with the remaining
+// spots of each. from defaults to today, or the class start if later, and to defaults to
+// four weeks after from. The window is limited to maxOccurrenceWindowDays.
+func (s *ClassService) Occurrences(ctx context.Context, id primitive.ObjectID, from, to *models.CustomDate) ([]models.Occurrence, error) {
+	class, err := s.Classes.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	start := models.CustomDate(time.Now()).UTC()
+	if class.StartDate.String() > start.String() {
+		start = class.StartDate.UTC()
+	}
+	if from != nil {
+		start = from.UTC()
+	}
+	end := start.AddDays(defaultOccurrenceWindowDays - 1)
+	if to != nil {
+		end = to.UTC()
+	}
+	if end.String() < start.String() {
+		return nil, &ValidationError{Errors: []string{"to must not be before from"}}
+	}
+	if end.String() > start.AddDays(maxOccurrenceWindowDays-1).String() {
+		return nil, &ValidationError{Errors: []string{fmt.Sprintf("The window from from to to must not exceed %d days", maxOccurrenceWindowDays)}}
+	}
+
+	return s.withRemaining(ctx, class, start, end)
+}
+
+// withRemaining lists the sessions of a class between start and end with their booked and remaining spots
+func (s *ClassService) withRemaining(ctx context.Context, class *models.Class, start, end models.CustomDate) ([]models.Occurrence, error) {
+	occurrences := class.Occurrences(start, end)
+	if len(occurrences) == 0 {
+		return []models.Occurrence{}, nil
+	}
+	booked, err := s.Bookings.CountBooked(ctx, class.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for i := range occurrences {
+		occurrences[i].Booked = booked[occurrences[i].Date.String()]
+		occurrences[i].Remaining = max(occurrences[i].Capacity-occurrences[i].Booked, 0)
+	}
+	return occurrences, nil
+}
+
+// cancelAll cancels each booking with the reason returned for it. Bookings cancelled
+// concurrently by someone else are skipped.
+func (s *ClassService) cancelAll(ctx context.Context, bookings []models.Booking, reason func(models.Booking) string) ([]models.Booking, error) {
+	cancelled := make([]models.Booking, 0, len(bookings))
+	for _, b := range bookings {
+		booking, err := s.Bookings.Cancel(ctx, b.ID, reason(b))
+		if errors.Is(err, storage.ErrAlreadyCancelled) || errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return cancelled, err
+		}
+		log.Info().Ctx(ctx).Msgf("Booking cancelled by class change: %v", booking)
+		cancelled = append(cancelled, *booking)
+	}
+	return cancelled, nil
+}
+
+// affectedBookings returns the bookings from today on that do not fit the class: those whose
+// date is no longer an occurrence of the class and, per date, the most recent ones beyond the
+// capacity. bookings must be ordered oldest first so that earlier bookings keep their spot.
+func affectedBookings(class models.Class, bookings []models.Booking, today models.CustomDate) []models.Booking {
+	var affected []models.Booking
+	perDate := make(map[string]int)
+	for _, b := range bookings {
+		if b.Date.String() < today.String() {
+			continue
+		}
+		if !class.OccursOn(b.Date) {
+			affected = append(affected, b)
+			continue
+		}
+		perDate[b.Date.String()]++
+		if perDate[b.Date.String()] > class.Capacity {
+			affected = append(affected, b)
+		}
+	}
+	return affected
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAffectedBookings(t *testing.T) {
+	day := func(d int) models.CustomDate {
+		return models.CustomDate(time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC))
+	}
+	booking := func(d int) models.Booking {
+		return models.Booking{ID: primitive.NewObjectID(), Date: day(d)}
+	}
+	// Oldest first, as returned by GetActiveByClass
+	bookings := []models.Booking{booking(5), booking(5), booking(1), booking(5), booking(20)}
+
+	tests := []struct {
+		name     string
+		class    models.Class
+		today    models.CustomDate
+		expected []models.Booking
+	}{
+		{
+			name:     "nothing affected when the class still fits",
+			class:    models.Class{StartDate: day(1), EndDate: day(31), Capacity: 3},
+			expected: nil,
+		},
+		{
+			name:     "most recent bookings over the capacity are affected",
+			class:    models.Class{StartDate: day(1), EndDate: day(31), Capacity: 2},
+			expected: []models.Booking{bookings[3]},
+		},
+		{
+			name:     "bookings outside the new range are affected",
+			class:    models.Class{StartDate: day(2), EndDate: day(10), Capacity: 3},
+			expected: []models.Booking{bookings[2], bookings[4]},
+		},
+		{
+			name:     "range and capacity changes combine",
+			class:    models.Class{StartDate: day(2), EndDate: day(10), Capacity: 1},
+			expected: []models.Booking{bookings[1], bookings[2], bookings[3], bookings[4]},
+		},
+		{
+			name:     "past bookings are neither affected nor counted",
+			class:    models.Class{StartDate: day(2), EndDate: day(10), Capacity: 1},
+			today:    day(6),
+			expected: []models.Booking{bookings[4]},
+		},
+		{
+			name:     "bookings of today are affected",
+			class:    models.Class{StartDate: day(1), EndDate: day(31), Capacity: 2},
+			today:    day(5),
+			expected: []models.Booking{bookings[3]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			today := tt.today
+			if today.IsZero() {
+				today = day(1)
+			}
+			assert.Equal(t, tt.expected, affectedBookings(tt.class, bookings, today))
+		})
+	}
+}
+
+func TestClassServiceGetAll(t *testing.T) {
+	day := func(d int) models.CustomDate {
+		return models.CustomDate(time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC))
+	}
+	class := func(name string, capacity int, recurrence *models.RecurrenceRule) models.Class {
+		return models.Class{ID: primitive.NewObjectID(), Name: name, StartDate: day(1), EndDate: day(31), Capacity: capacity, Recurrence: recurrence}
+	}
+	// Monday 3 to Wednesday 5 March 2025
+	from, to := day(3), day(5)
+	window := storage.ClassFilter{From: &from, To: &to}
+	weekends := class("Weekend Yoga", 5, &models.RecurrenceRule{Days: []string{"sat", "sun"}})
+	full := class("Pilates", 1, nil)
+	spotsLeft := class("Spinning", 2, nil)
+	empty := class("Boxing", 5, nil)
+
+	t.Run("without a window classes come straight from the repository", func(t *testing.T) {
+		classes := new(MockClassRepository)
+		svc := NewClassService(classes, new(MockBookingRepository), new(MockWaitlistRepository))
+		filter := storage.ClassFilter{Name: "yoga"}
+		classes.On("GetAll", mock.Anything, filter, storage.Page{Limit: 2}).Return([]models.Class{weekends}, nil)
+
+		result, next, err := svc.GetAll(context.Background(), ClassSearch{ClassFilter: filter}, storage.Page{Limit: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Class{weekends}, result)
+		assert.Empty(t, next)
+	})
+
+	t.Run("only classes with a session in the window", func(t *testing.T) {
+		classes := new(MockClassRepository)
+		svc := NewClassService(classes, new(MockBookingRepository), new(MockWaitlistRepository))
+		classes.On("GetAll", mock.Anything, window, storage.Page{}).Return([]models.Class{weekends, full}, nil)
+
+		result, _, err := svc.GetAll(context.Background(), ClassSearch{ClassFilter: window}, storage.Page{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Class{full}, result)
+	})
+
+	t.Run("minimum spots fetches batches until the page is full", func(t *testing.T) {
+		classes := new(MockClassRepository)
+		bookings := new(MockBookingRepository)
+		svc := NewClassService(classes, bookings, new(MockWaitlistRepository))
+
+		classes.On("GetAll", mock.Anything, window, storage.Page{Limit: 2}).Return([]models.Class{weekends, full}, nil)
+		classes.On("GetAll", mock.Anything, window, storage.Page{After: full.ID, Limit: 2}).Return([]models.Class{spotsLeft, empty}, nil)
+		bookings.On("CountBooked", mock.Anything, full.ID, from, to).Return(map[string]int{"2025-03-03": 1, "2025-03-04": 1, "2025-03-05": 1}, nil)
+		bookings.On("CountBooked", mock.Anything, spotsLeft.ID, from, to).Return(map[string]int{"2025-03-03": 2, "2025-03-04": 1}, nil)
+		bookings.On("CountBooked", mock.Anything, empty.ID, from, to).Return(map[string]int{}, nil)
+
+		result, next, err := svc.GetAll(context.Background(), ClassSearch{ClassFilter: window, MinSpots: 2}, storage.Page{Limit: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Class{spotsLeft}, result)
+		expectedNext, _ := EncodeCursor(spotsLeft, storage.Sort{})
+		assert.Equal(t, expectedNext, next)
+		bookings.AssertNotCalled(t, "CountBooked", mock.Anything, weekends.ID, mock.Anything, mock.Anything)
+	})
+
+	t.Run("window longer than a year is rejected", func(t *testing.T) {
+		svc := NewClassService(new(MockClassRepository), new(MockBookingRepository), new(MockWaitlistRepository))
+		end := from.AddDays(maxOccurrenceWindowDays)
+
+		_, _, err := svc.GetAll(context.Background(), ClassSearch{ClassFilter: storage.ClassFilter{From: &from, To: &end}}, storage.Page{})
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+)
+
+// ErrMemberNotFound is returned when a booking references a member that does not exist
+var ErrMemberNotFound = errors.New("Member not found")
+
+// ValidationError reports the request fields that failed business validation
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return "Validation failed"
+}
+
+// AffectedBookingsError is returned when a class change would invalidate active bookings
+// and the caller did not ask for them to be cancelled
+type AffectedBookingsError struct {
+	Bookings []models.Booking
+}
+
+func (e *AffectedBookingsError) Error() string {
+	return fmt.Sprintf("%d active bookings are affected by this change, retry with force=true to cancel them", len(e.Bookings))
+}
+
+// BookingIDs lists the IDs of the affected bookings
+func (e *AffectedBookingsError) BookingIDs() []string {
+	ids := make([]string, 0, len(e.Bookings))
+	for _, b := range e.Bookings {
+		ids = append(ids, b.ID.Hex())
+	}
+	return ids
+}
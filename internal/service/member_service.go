@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemberService holds the rules for managing members
+type MemberService struct {
+	Members storage.MemberRepositoryInterface
+}
+
+// NewMemberService initializes a MemberService with DI
+func NewMemberService(members storage.MemberRepositoryInterface) *MemberService {
+	return &MemberService{Members: members}
+}
+
+// normalizeMember trims the member fields, lowercases the email so uniqueness is
+// case-insensitive and defaults the status to active
+func normalizeMember(member *models.Member) {
+	member.Name = strings.TrimSpace(member.Name)
+	member.Email = strings.ToLower(strings.TrimSpace(member.Email))
+	if member.Status == "" {
+		member.Status = models.MemberStatusActive
+	}
+}
+
+// Create stores a new member and returns its ID, or storage.ErrEmailTaken
+func (s *MemberService) Create(ctx context.Context, member *models.Member) (primitive.ObjectID, error) {
+	normalizeMember(member)
+	member.CreatedAt = time.Now().UTC()
+	return s.Members.Create(ctx, member)
+}
+
+// GetAll returns every member
+func (s *MemberService) GetAll(ctx context.Context) ([]models.Member, error) {
+	return s.Members.GetAll(ctx)
+}
+
+// Get returns a member, or storage.ErrNotFound
+func (s *MemberService) Get(ctx context.Context, id primitive.ObjectID) (*models.Member, error) {
+	return s.Members.GetByID(ctx, id)
+}
+
+// Update replaces a member, keeping its creation time
+func (s *MemberService) Update(ctx context.Context, member *models.Member) error {
+	existing, err := s.Members.GetByID(ctx, member.ID)
+	if err != nil {
+		return err
+	}
+	normalizeMember(member)
+	member.CreatedAt = existing.CreatedAt
+	return s.Members.Update(ctx, member)
+}
+
+// Delete removes a member. Their bookings are kept and still show the member name.
+func (s *MemberService) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return s.Members.Delete(ctx, id)
+}
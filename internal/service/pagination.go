@@ -0,0 +1,114 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Page sizes of the list endpoints
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+// cursorPrefix versions the cursor format, a BSON cursor document, so the format can
+// change without breaking clients mid-listing
+const cursorPrefix = "v2:"
+
+// ErrInvalidCursor is returned when a cursor was not produced by EncodeCursor for the same sort
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
+// cursor is the position of the last item of a page
+type cursor struct {
+	ID   primitive.ObjectID `bson:"id"`
+	Sort string             `bson:"sort,omitempty"`
+	Key  bson.RawValue      `bson:"key,omitempty"`
+}
+
+// sortName identifies a sort in cursors, as the field name prefixed with "-" when descending
+func sortName(sort storage.Sort) string {
+	if sort.Desc {
+		return "-" + sort.Field
+	}
+	return sort.Field
+}
+
+// position returns the cursor of item in a listing with the given sort
+func position(item interface{}, sort storage.Sort) (cursor, error) {
+	doc, err := bson.Marshal(item)
+	if err != nil {
+		return cursor{}, err
+	}
+	id, ok := bson.Raw(doc).Lookup("_id").ObjectIDOK()
+	if !ok {
+		return cursor{}, errors.New("item has no ObjectID")
+	}
+	c := cursor{ID: id, Sort: sortName(sort)}
+	if sort.Field != "" {
+		c.Key = bson.Raw(doc).Lookup(sort.Field)
+	}
+	return c, nil
+}
+
+// EncodeCursor returns the opaque cursor of the page following item in a listing with the given sort
+func EncodeCursor(item interface{}, sort storage.Sort) (string, error) {
+	c, err := position(item, sort)
+	if err != nil {
+		return "", err
+	}
+	doc, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append([]byte(cursorPrefix), doc...)), nil
+}
+
+// DecodeCursor returns the ID and sort key a cursor starts after, for a listing with the given sort
+func DecodeCursor(value string, sort storage.Sort) (primitive.ObjectID, bson.RawValue, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || !bytes.HasPrefix(data, []byte(cursorPrefix)) {
+		return primitive.NilObjectID, bson.RawValue{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := bson.Unmarshal(data[len(cursorPrefix):], &c); err != nil || c.ID.IsZero() {
+		return primitive.NilObjectID, bson.RawValue{}, ErrInvalidCursor
+	}
+	if c.Sort != sortName(sort) || (sort.Field != "" && c.Key.Type == 0) {
+		return primitive.NilObjectID, bson.RawValue{}, ErrInvalidCursor
+	}
+	return c.ID, c.Key, nil
+}
+
+// lookahead asks the repository for one item more than the page holds, to know whether another page follows
+func lookahead(page storage.Page) storage.Page {
+	if page.Limit > 0 {
+		page.Limit++
+	}
+	return page
+}
+
+// advance moves a page to start after item
+func advance(page storage.Page, item interface{}) (storage.Page, error) {
+	c, err := position(item, page.Sort)
+	if err != nil {
+		return page, err
+	}
+	page.After, page.AfterKey = c.ID, c.Key
+	return page, nil
+}
+
+// trimPage cuts the lookahead item off a page, returning the cursor of the next page or "" on the last one
+func trimPage[T any](items []T, page storage.Page) ([]T, string, error) {
+	if page.Limit <= 0 || len(items) <= page.Limit {
+		return items, "", nil
+	}
+	items = items[:page.Limit]
+	next, err := EncodeCursor(items[len(items)-1], page.Sort)
+	return items, next, err
+}
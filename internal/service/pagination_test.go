@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursor(t *testing.T) {
+	class := models.Class{ID: primitive.NewObjectID(), Name: "Yoga", Capacity: 10}
+	byName := storage.Sort{Field: "name"}
+
+	t.Run("round trip in ID order", func(t *testing.T) {
+		cursor, err := EncodeCursor(class, storage.Sort{})
+		assert.NoError(t, err)
+
+		id, key, err := DecodeCursor(cursor, storage.Sort{})
+		assert.NoError(t, err)
+		assert.Equal(t, class.ID, id)
+		assert.Zero(t, key.Type)
+	})
+
+	t.Run("round trip keeps the sort key", func(t *testing.T) {
+		cursor, err := EncodeCursor(class, byName)
+		assert.NoError(t, err)
+
+		id, key, err := DecodeCursor(cursor, byName)
+		assert.NoError(t, err)
+		assert.Equal(t, class.ID, id)
+		assert.Equal(t, "Yoga", key.StringValue())
+	})
+
+	t.Run("cursor of another sort is rejected", func(t *testing.T) {
+		cursor, _ := EncodeCursor(class, byName)
+		_, _, err := DecodeCursor(cursor, storage.Sort{Field: "name", Desc: true})
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	for _, cursor := range []string{"not-a-cursor", "djI6", "djM6NjdlYWNkOWY0YWVkMzkzMmE2ZDk2NmEz", class.ID.Hex()} {
+		t.Run("rejects "+cursor, func(t *testing.T) {
+			_, _, err := DecodeCursor(cursor, storage.Sort{})
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	classes := []models.Class{{ID: primitive.NewObjectID()}, {ID: primitive.NewObjectID()}, {ID: primitive.NewObjectID()}}
+	cursorOf := func(class models.Class) string {
+		cursor, _ := EncodeCursor(class, storage.Sort{})
+		return cursor
+	}
+
+	tests := []struct {
+		name         string
+		limit        int
+		expectedLen  int
+		expectedNext string
+	}{
+		{"more items than the limit", 2, 2, cursorOf(classes[1])},
+		{"exactly the limit", 3, 3, ""},
+		{"no limit", 0, 3, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := storage.Page{Limit: tt.limit}
+			assert.Equal(t, tt.limit+min(tt.limit, 1), lookahead(page).Limit)
+
+			items, next, err := trimPage(classes, page)
+			assert.NoError(t, err)
+			assert.Len(t, items, tt.expectedLen)
+			assert.Equal(t, tt.expectedNext, next)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Storage backends selected by STORAGE_BACKEND. The postgres backend lives in the postgres package.
+const (
+	BackendMongo    = "mongo"
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// Backend is the set of repositories of one storage backend
+type Backend struct {
+	Classes  ClassRepositoryInterface
+	Bookings BookingRepositoryInterface
+	Waitlist WaitlistRepositoryInterface
+	Members  MemberRepositoryInterface
+	// Mongo is the MongoDB connection of the mongo backend, nil for the other backends
+	Mongo *MongoRepository
+	// Pinger checks the database of the backend answers, nil when there is no database
+	Pinger func(ctx context.Context) error
+	// Closer releases the connections of the backend, nil when it holds none
+	Closer func(ctx context.Context) error
+}
+
+// Ping checks the database of the backend answers
+func (b *Backend) Ping(ctx context.Context) error {
+	if b.Pinger == nil {
+		return nil
+	}
+	return b.Pinger(ctx)
+}
+
+// Close releases the connections of the backend. Operations still running when ctx is
+// done are interrupted.
+func (b *Backend) Close(ctx context.Context) error {
+	if b.Closer == nil {
+		return nil
+	}
+	return b.Closer(ctx)
+}
+
+// NewMongoBackend returns the repositories of a MongoDB connection
+func NewMongoBackend(m *MongoRepository) *Backend {
+	return &Backend{
+		Classes:  NewClassRepository(m.Collection(m.Collections.Classes)),
+		Bookings: NewBookingRepository(m.Collection(m.Collections.Bookings), m.Collection(m.Collections.Occurrences)),
+		Waitlist: NewWaitlistRepository(m.Collection(m.Collections.Waitlist)),
+		Members:  NewMemberRepository(m.Collection(m.Collections.Members)),
+		Mongo:    m,
+		// Bookings are written, so the backend is only usable while it reaches the primary
+		Pinger: func(ctx context.Context) error { return m.Client.Ping(ctx, readpref.Primary()) },
+		Closer: m.Client.Disconnect,
+	}
+}
+
+// NewMemoryBackend returns the repositories of a MemoryStore
+func NewMemoryBackend(store *MemoryStore) *Backend {
+	return &Backend{
+		Classes:  NewMemoryClassRepository(store),
+		Bookings: NewMemoryBookingRepository(store),
+		Waitlist: NewMemoryWaitlistRepository(store),
+		Members:  NewMemoryMemberRepository(store),
+	}
+}
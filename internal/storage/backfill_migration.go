@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BackfillBookingStatus marks the bookings stored before bookings had a status as active,
+// which is what they were, and returns how many it marked. Running it again is safe.
+func BackfillBookingStatus(ctx context.Context, m *MongoRepository) (int64, error) {
+	result, err := m.Collection(m.Collections.Bookings).UpdateMany(ctx,
+		bson.M{"status": bson.M{"$in": bson.A{nil, ""}}},
+		bson.M{"$set": bson.M{"status": models.BookingStatusActive}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to backfill booking status: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// BackfillOccurrences brings the booked counter of every class occurrence up to its active
+// bookings, creating the counters of occurrences booked before counters existed, and
+// returns how many counters it created or raised. A counter is never lowered, so spots
+// reserved by bookings being created meanwhile are kept. Running it again is safe.
+func BackfillOccurrences(ctx context.Context, m *MongoRepository) (int64, error) {
+	cursor, err := m.Collection(m.Collections.Bookings).Aggregate(ctx, mongo.Pipeline{
+		// Bookings whose date was lost in the date migration belong to no occurrence
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$ne": models.BookingStatusCancelled}, "date": bson.M{"$type": "date"}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    bson.M{"class_id": "$class_id", "date": "$date"},
+			"booked": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active bookings: %w", err)
+	}
+	var occurrences []struct {
+		ID struct {
+			ClassID primitive.ObjectID `bson:"class_id"`
+			Date    models.CustomDate  `bson:"date"`
+		} `bson:"_id"`
+		Booked int `bson:"booked"`
+	}
+	if err := cursor.All(ctx, &occurrences); err != nil {
+		return 0, fmt.Errorf("failed to read active booking counts: %w", err)
+	}
+
+	var updated int64
+	for start := 0; start < len(occurrences); start += copyBatchSize {
+		end := min(start+copyBatchSize, len(occurrences))
+		writes := make([]mongo.WriteModel, 0, end-start)
+		for _, o := range occurrences[start:end] {
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": occurrenceKey(o.ID.ClassID, o.ID.Date)}).
+				SetUpdate(bson.M{
+					"$max":         bson.M{"booked": o.Booked},
+					"$setOnInsert": bson.M{"class_id": o.ID.ClassID, "date": o.ID.Date},
+				}).
+				SetUpsert(true))
+		}
+		result, err := m.Collection(m.Collections.Occurrences).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return updated, fmt.Errorf("failed to backfill occurrence counters: %w", err)
+		}
+		updated += result.UpsertedCount + result.ModifiedCount
+	}
+	return updated, nil
+}
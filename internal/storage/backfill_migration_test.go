@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBackfillMigrations(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMigrator(t, nil).Mongo
+	bookings, occurrences := m.Collection(m.Collections.Bookings), m.Collection(m.Collections.Occurrences)
+	classID := primitive.NewObjectID()
+	march10 := models.CustomDate(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	march11 := march10.AddDays(1)
+
+	_, err := bookings.InsertMany(ctx, []interface{}{
+		bson.M{"class_id": classID, "date": march10, "member_name": "Jane"},
+		bson.M{"class_id": classID, "date": march10, "member_name": "John", "status": models.BookingStatusActive},
+		bson.M{"class_id": classID, "date": march10, "member_name": "Mary", "status": models.BookingStatusCancelled},
+		bson.M{"class_id": classID, "date": march11, "member_name": "Anna"},
+		// Dates lost in the date migration count nowhere
+		bson.M{"class_id": classID, "date": bson.M{}, "member_name": "Paul"},
+	})
+	require.NoError(t, err)
+	// A counter ahead of the bookings holds a spot being booked
+	_, err = occurrences.InsertOne(ctx, bson.M{"_id": occurrenceKey(classID, march11), "class_id": classID, "date": march11, "booked": 2})
+	require.NoError(t, err)
+
+	marked, err := BackfillBookingStatus(ctx, m)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), marked)
+	active, err := bookings.CountDocuments(ctx, bson.M{"status": models.BookingStatusActive})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), active)
+
+	updated, err := BackfillOccurrences(ctx, m)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+	booked, err := NewBookingRepository(bookings, occurrences).CountBooked(ctx, classID, march10, march11)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{march10.String(): 2, march11.String(): 2}, booked)
+
+	// Running them again changes nothing
+	marked, err = BackfillBookingStatus(ctx, m)
+	assert.NoError(t, err)
+	assert.Zero(t, marked)
+	updated, err = BackfillOccurrences(ctx, m)
+	assert.NoError(t, err)
+	assert.Zero(t, updated)
+}
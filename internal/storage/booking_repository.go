@@ -2,48 +2,222 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"regexp"
+	"time"
 
+	"github.com/rs/zerolog/log"
 	"github.com/sinhaseemant/glofox-backend/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // BookingRepositoryInterface defines the contract for BookingRepository
 type BookingRepositoryInterface interface {
-	Create(ctx context.Context, booking *models.Booking) (primitive.ObjectID, error)
-	GetAll(ctx context.Context) ([]models.Booking, error)
+	// Create reserves a spot on the booking's class occurrence and inserts the booking.
+	// It returns ErrClassFull if the occurrence already holds capacity bookings, or
+	// ErrAlreadyBooked if the member already has an active booking for it.
+	Create(ctx context.Context, booking *models.Booking, capacity int) (primitive.ObjectID, error)
+	// GetAll returns the bookings matching the filter, cancelled ones included unless
+	// filtered by status, so history stays visible.
+	GetAll(ctx context.Context, filter BookingFilter, page Page) ([]models.Booking, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Booking, error)
+	// CountBooked returns the number of booked spots per date ("YYYY-MM-DD") of a class between from and to.
+	CountBooked(ctx context.Context, classID primitive.ObjectID, from, to models.CustomDate) (map[string]int, error)
+	// GetActiveByClass returns the bookings of a class dated from on that are not cancelled, oldest first.
+	GetActiveByClass(ctx context.Context, classID primitive.ObjectID, from models.CustomDate) ([]models.Booking, error)
+	// Cancel soft-cancels a booking and releases its spot back to the class occurrence.
+	Cancel(ctx context.Context, id primitive.ObjectID, reason string) (*models.Booking, error)
+}
+
+// BookingFilter narrows down the bookings returned by GetAll. Zero fields are ignored.
+type BookingFilter struct {
+	ClassID    primitive.ObjectID
+	MemberID   primitive.ObjectID
+	MemberName string // Case-insensitive exact match, for bookings made before members existed
+	Date       *models.CustomDate
+	From       *models.CustomDate // Inclusive
+	To         *models.CustomDate // Inclusive
+	Status     string             // active also matches the bookings stored before bookings had a status
+}
+
+// query translates the filter to a MongoDB filter document
+func (f BookingFilter) query() bson.M {
+	query := bson.M{}
+	if !f.ClassID.IsZero() {
+		query["class_id"] = f.ClassID
+	}
+	if !f.MemberID.IsZero() {
+		query["member_id"] = f.MemberID
+	}
+	if f.MemberName != "" {
+		query["member_name"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(f.MemberName) + "$", Options: "i"}
+	}
+	date := bson.M{}
+	if f.Date != nil {
+		date["$eq"] = *f.Date
+	}
+	if f.From != nil {
+		date["$gte"] = *f.From
+	}
+	if f.To != nil {
+		date["$lte"] = *f.To
+	}
+	if len(date) > 0 {
+		query["date"] = date
+	}
+	switch f.Status {
+	case "":
+	case models.BookingStatusActive:
+		query["status"] = bson.M{"$ne": models.BookingStatusCancelled}
+	default:
+		query["status"] = f.Status
+	}
+	return query
 }
 
 // BookingRepository struct for MongoDB
 type BookingRepository struct {
 	Collection *mongo.Collection
+	// Occurrences holds one counter document per (class, date) tracking booked spots
+	Occurrences *mongo.Collection
+}
+
+// NewBookingRepository initializes a BookingRepository with the MongoDB collections of
+// bookings and of their class occurrence counters
+func NewBookingRepository(collection, occurrences *mongo.Collection) BookingRepositoryInterface {
+	return &BookingRepository{Collection: collection, Occurrences: occurrences}
 }
 
-// NewBookingRepository initializes a BookingRepository with MongoDB collection
-func NewBookingRepository(db *mongo.Database) BookingRepositoryInterface {
-	collection := db.Collection("bookings")
-	return &BookingRepository{Collection: collection}
+// occurrenceKey builds the counter document ID for a class on a given date. Using a
+// deterministic _id lets the unique _id index serialise concurrent reservations.
+func occurrenceKey(classID primitive.ObjectID, date models.CustomDate) string {
+	return fmt.Sprintf("%s:%s", classID.Hex(), date.String())
 }
 
-// Create inserts a new booking into the MongoDB collection
-func (r *BookingRepository) Create(ctx context.Context, booking *models.Booking) (primitive.ObjectID, error) {
+// seedOccurrence creates the counter of a class occurrence that has none, starting at the
+// active bookings already stored for it: bookings made before counters existed, or copied
+// from another database, take their spots. A concurrent seed of the same counter wins.
+func (r *BookingRepository) seedOccurrence(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) error {
+	key := occurrenceKey(classID, date)
+	err := r.Occurrences.FindOne(ctx, bson.M{"_id": key}, options.FindOne().SetProjection(bson.M{"_id": 1})).Err()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding occurrence counter")
+		return fmt.Errorf("failed to find occurrence counter: %w", err)
+	}
+
+	filter := bson.M{"class_id": classID, "date": date, "status": bson.M{"$ne": models.BookingStatusCancelled}}
+	booked, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error counting active bookings")
+		return fmt.Errorf("failed to count active bookings: %w", err)
+	}
+	_, err = r.Occurrences.InsertOne(ctx, bson.M{"_id": key, "class_id": classID, "date": date, "booked": booked})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		log.Error().Ctx(ctx).Err(err).Msg("Error seeding occurrence counter")
+		return fmt.Errorf("failed to seed occurrence counter: %w", err)
+	}
+	return nil
+}
+
+// reserveSpot atomically increments the booked counter for a class occurrence as long
+// as it is below capacity. When the occurrence is full the filter does not match and
+// ErrClassFull is returned.
+func (r *BookingRepository) reserveSpot(ctx context.Context, classID primitive.ObjectID, date models.CustomDate, capacity int) error {
+	if capacity <= 0 {
+		return ErrClassFull
+	}
+	if err := r.seedOccurrence(ctx, classID, date); err != nil {
+		return err
+	}
+	filter := bson.M{"_id": occurrenceKey(classID, date), "booked": bson.M{"$lt": capacity}}
+	result, err := r.Occurrences.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"booked": 1}})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error reserving spot")
+		return fmt.Errorf("failed to reserve spot: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrClassFull
+	}
+	return nil
+}
+
+// releaseSpot gives a previously reserved spot back to the class occurrence
+func (r *BookingRepository) releaseSpot(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) error {
+	filter := bson.M{"_id": occurrenceKey(classID, date), "booked": bson.M{"$gt": 0}}
+	_, err := r.Occurrences.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"booked": -1}})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error releasing spot")
+		return fmt.Errorf("failed to release spot: %w", err)
+	}
+	return nil
+}
+
+// hasActiveBooking reports whether the member of the booking already has an active
+// booking for the same class and date
+func (r *BookingRepository) hasActiveBooking(ctx context.Context, booking *models.Booking) (bool, error) {
+	if booking.MemberID.IsZero() {
+		return false, nil
+	}
+	filter := bson.M{
+		"member_id": booking.MemberID,
+		"class_id":  booking.ClassID,
+		"date":      booking.Date,
+		"status":    models.BookingStatusActive,
+	}
+	count, err := r.Collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error checking for an existing booking")
+		return false, fmt.Errorf("failed to check for an existing booking: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Create reserves a spot on the class occurrence and inserts the booking into the MongoDB collection.
+// Concurrent duplicate bookings are caught by the unique index created in EnsureIndexes.
+func (r *BookingRepository) Create(ctx context.Context, booking *models.Booking, capacity int) (primitive.ObjectID, error) {
+	if err := r.reserveSpot(ctx, booking.ClassID, booking.Date, capacity); err != nil {
+		if !errors.Is(err, ErrClassFull) {
+			return primitive.NilObjectID, err
+		}
+		// A full class is the less useful answer when the member is already booked on it
+		booked, checkErr := r.hasActiveBooking(ctx, booking)
+		if checkErr != nil {
+			return primitive.NilObjectID, checkErr
+		}
+		if booked {
+			return primitive.NilObjectID, ErrAlreadyBooked
+		}
+		return primitive.NilObjectID, err
+	}
+
 	res, err := r.Collection.InsertOne(ctx, booking)
 	if err != nil {
-		log.Printf("Error inserting booking: %v", err)
+		if releaseErr := r.releaseSpot(ctx, booking.ClassID, booking.Date); releaseErr != nil {
+			log.Error().Ctx(ctx).Err(releaseErr).Msg("Error rolling back reserved spot")
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return primitive.NilObjectID, ErrAlreadyBooked
+		}
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting booking")
 		return primitive.NilObjectID, fmt.Errorf("failed to insert booking: %w", err)
 	}
-	log.Printf("Inserted booking with ID: %v", res.InsertedID)
+	log.Info().Ctx(ctx).Msgf("Inserted booking with ID: %v", res.InsertedID)
 	return res.InsertedID.(primitive.ObjectID), nil
 }
 
-// GetAll retrieves all bookings from the MongoDB collection
-func (r *BookingRepository) GetAll(ctx context.Context) ([]models.Booking, error) {
-	cursor, err := r.Collection.Find(ctx, bson.M{})
+// GetAll retrieves a page of the bookings matching the filter from the MongoDB collection
+func (r *BookingRepository) GetAll(ctx context.Context, filter BookingFilter, page Page) ([]models.Booking, error) {
+	query, opts := page.apply(filter.query())
+	cursor, err := r.Collection.Find(ctx, query, opts)
 	if err != nil {
-		log.Printf("Error finding bookings: %v", err)
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding bookings")
 		return nil, fmt.Errorf("failed to find bookings: %w", err)
 	}
 	defer cursor.Close(ctx)
@@ -52,16 +226,110 @@ func (r *BookingRepository) GetAll(ctx context.Context) ([]models.Booking, error
 	for cursor.Next(ctx) {
 		var booking models.Booking
 		if err := cursor.Decode(&booking); err != nil {
-			log.Printf("Error decoding booking: %v", err)
+			log.Error().Ctx(ctx).Err(err).Msg("Error decoding booking")
 			return nil, fmt.Errorf("failed to decode booking: %w", err)
 		}
 		bookings = append(bookings, booking)
 	}
 
 	if err := cursor.Err(); err != nil {
-		log.Printf("Cursor error: %v", err)
+		log.Error().Ctx(ctx).Err(err).Msg("Cursor error")
 		return nil, fmt.Errorf("cursor error: %w", err)
 	}
 
 	return bookings, nil
 }
+
+// CountBooked reads the occurrence counters of a class between two dates, inclusive
+func (r *BookingRepository) CountBooked(ctx context.Context, classID primitive.ObjectID, from, to models.CustomDate) (map[string]int, error) {
+	filter := bson.M{
+		"class_id": classID,
+		"date":     bson.M{"$gte": from, "$lte": to},
+	}
+	cursor, err := r.Occurrences.Find(ctx, filter)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding occurrences for class %s", classID.Hex())
+		return nil, fmt.Errorf("failed to find occurrences: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var counters []struct {
+		Date   models.CustomDate `bson:"date"`
+		Booked int               `bson:"booked"`
+	}
+	if err := cursor.All(ctx, &counters); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error decoding occurrences")
+		return nil, fmt.Errorf("failed to decode occurrences: %w", err)
+	}
+
+	booked := make(map[string]int, len(counters))
+	for _, c := range counters {
+		booked[c.Date.String()] = c.Booked
+	}
+	return booked, nil
+}
+
+// GetActiveByClass retrieves the active bookings of a class dated from on, in creation order
+func (r *BookingRepository) GetActiveByClass(ctx context.Context, classID primitive.ObjectID, from models.CustomDate) ([]models.Booking, error) {
+	filter := bson.M{"class_id": classID, "date": bson.M{"$gte": from}, "status": bson.M{"$ne": models.BookingStatusCancelled}}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding bookings for class %s", classID.Hex())
+		return nil, fmt.Errorf("failed to find bookings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var bookings []models.Booking
+	if err := cursor.All(ctx, &bookings); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error decoding bookings")
+		return nil, fmt.Errorf("failed to decode bookings: %w", err)
+	}
+	return bookings, nil
+}
+
+// GetByID retrieves a single booking by its ID, returning ErrNotFound if it does not exist
+func (r *BookingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Booking, error) {
+	var booking models.Booking
+	err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&booking)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding booking %s", id.Hex())
+		return nil, fmt.Errorf("failed to find booking: %w", err)
+	}
+	return &booking, nil
+}
+
+// Cancel marks a booking as cancelled and releases its spot. The status filter makes the
+// transition happen at most once, so concurrent cancellations release a single spot.
+func (r *BookingRepository) Cancel(ctx context.Context, id primitive.ObjectID, reason string) (*models.Booking, error) {
+	filter := bson.M{"_id": id, "status": bson.M{"$ne": models.BookingStatusCancelled}}
+	update := bson.M{"$set": bson.M{
+		"status":              models.BookingStatusCancelled,
+		"cancelled_at":        time.Now().UTC(),
+		"cancellation_reason": reason,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var booking models.Booking
+	err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&booking)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Either the booking does not exist or it was already cancelled
+		if _, getErr := r.GetByID(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, ErrAlreadyCancelled
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error cancelling booking %s", id.Hex())
+		return nil, fmt.Errorf("failed to cancel booking: %w", err)
+	}
+
+	if err := r.releaseSpot(ctx, booking.ClassID, booking.Date); err != nil {
+		return nil, err
+	}
+	log.Info().Ctx(ctx).Msgf("Cancelled booking with ID: %v", id)
+	return &booking, nil
+}
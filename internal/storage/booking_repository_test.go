@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBookingFilterQuery(t *testing.T) {
+	classID := primitive.NewObjectID()
+	memberID := primitive.NewObjectID()
+	from := models.CustomDate(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	to := models.CustomDate(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		filter   BookingFilter
+		expected bson.M
+	}{
+		{"empty filter matches everything", BookingFilter{}, bson.M{}},
+		{
+			"class, member and status",
+			BookingFilter{ClassID: classID, MemberID: memberID, Status: models.BookingStatusActive},
+			bson.M{"class_id": classID, "member_id": memberID, "status": bson.M{"$ne": models.BookingStatusCancelled}},
+		},
+		{
+			"cancelled status",
+			BookingFilter{Status: models.BookingStatusCancelled},
+			bson.M{"status": models.BookingStatusCancelled},
+		},
+		{
+			"member name is an escaped case-insensitive match",
+			BookingFilter{MemberName: "J. Doe"},
+			bson.M{"member_name": primitive.Regex{Pattern: `^J\. Doe$`, Options: "i"}},
+		},
+		{"single date", BookingFilter{Date: &from}, bson.M{"date": bson.M{"$eq": from}}},
+		{"date range", BookingFilter{From: &from, To: &to}, bson.M{"date": bson.M{"$gte": from, "$lte": to}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.query())
+		})
+	}
+}
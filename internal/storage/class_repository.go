@@ -2,9 +2,11 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"regexp"
 
+	"github.com/rs/zerolog/log"
 	"github.com/sinhaseemant/glofox-backend/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,7 +16,32 @@ import (
 // ClassRepositoryInterface defines the contract for ClassRepository
 type ClassRepositoryInterface interface {
 	Create(ctx context.Context, class *models.Class) (primitive.ObjectID, error)
-	GetAll(ctx context.Context) ([]models.Class, error)
+	GetAll(ctx context.Context, filter ClassFilter, page Page) ([]models.Class, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Class, error)
+	Update(ctx context.Context, class *models.Class) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// ClassFilter narrows down the classes returned by GetAll. Zero fields are ignored.
+type ClassFilter struct {
+	Name string             // Case-insensitive substring
+	From *models.CustomDate // Classes ending on or after this date
+	To   *models.CustomDate // Classes starting on or before this date
+}
+
+// query translates the filter to a MongoDB filter document
+func (f ClassFilter) query() bson.M {
+	query := bson.M{}
+	if f.Name != "" {
+		query["name"] = primitive.Regex{Pattern: regexp.QuoteMeta(f.Name), Options: "i"}
+	}
+	if f.From != nil {
+		query["end_date"] = bson.M{"$gte": *f.From}
+	}
+	if f.To != nil {
+		query["start_date"] = bson.M{"$lte": *f.To}
+	}
+	return query
 }
 
 // ClassRepository struct for MongoDB
@@ -23,8 +50,7 @@ type ClassRepository struct {
 }
 
 // NewClassRepository initializes a ClassRepository with MongoDB collection
-func NewClassRepository(db *mongo.Database) ClassRepositoryInterface {
-	collection := db.Collection("classes")
+func NewClassRepository(collection *mongo.Collection) ClassRepositoryInterface {
 	return &ClassRepository{Collection: collection}
 }
 
@@ -32,18 +58,19 @@ func NewClassRepository(db *mongo.Database) ClassRepositoryInterface {
 func (r *ClassRepository) Create(ctx context.Context, class *models.Class) (primitive.ObjectID, error) {
 	res, err := r.Collection.InsertOne(ctx, class)
 	if err != nil {
-		log.Printf("Error inserting class: %v", err)
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting class")
 		return primitive.NilObjectID, fmt.Errorf("failed to insert class: %w", err)
 	}
-	log.Printf("Inserted class with ID: %v", res.InsertedID)
+	log.Info().Ctx(ctx).Msgf("Inserted class with ID: %v", res.InsertedID)
 	return res.InsertedID.(primitive.ObjectID), nil
 }
 
-// GetAll retrieves all classes from the MongoDB collection
-func (r *ClassRepository) GetAll(ctx context.Context) ([]models.Class, error) {
-	cursor, err := r.Collection.Find(ctx, bson.M{})
+// GetAll retrieves a page of the classes matching the filter from the MongoDB collection
+func (r *ClassRepository) GetAll(ctx context.Context, filter ClassFilter, page Page) ([]models.Class, error) {
+	query, opts := page.apply(filter.query())
+	cursor, err := r.Collection.Find(ctx, query, opts)
 	if err != nil {
-		log.Printf("Error finding classes: %v", err)
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding classes")
 		return nil, fmt.Errorf("failed to find classes: %w", err)
 	}
 	defer cursor.Close(ctx)
@@ -52,16 +79,58 @@ func (r *ClassRepository) GetAll(ctx context.Context) ([]models.Class, error) {
 	for cursor.Next(ctx) {
 		var class models.Class
 		if err := cursor.Decode(&class); err != nil {
-			log.Printf("Error decoding class: %v", err)
+			log.Error().Ctx(ctx).Err(err).Msg("Error decoding class")
 			return nil, fmt.Errorf("failed to decode class: %w", err)
 		}
 		classes = append(classes, class)
 	}
 
 	if err := cursor.Err(); err != nil {
-		log.Printf("Cursor error: %v", err)
+		log.Error().Ctx(ctx).Err(err).Msg("Cursor error")
 		return nil, fmt.Errorf("cursor error: %w", err)
 	}
 
 	return classes, nil
 }
+
+// GetByID retrieves a single class by its ID, returning ErrNotFound if it does not exist
+func (r *ClassRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Class, error) {
+	var class models.Class
+	err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&class)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding class %s", id.Hex())
+		return nil, fmt.Errorf("failed to find class: %w", err)
+	}
+	return &class, nil
+}
+
+// Update replaces a stored class, returning ErrNotFound if it does not exist
+func (r *ClassRepository) Update(ctx context.Context, class *models.Class) error {
+	res, err := r.Collection.ReplaceOne(ctx, bson.M{"_id": class.ID}, class)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error updating class %s", class.ID.Hex())
+		return fmt.Errorf("failed to update class: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Updated class with ID: %v", class.ID)
+	return nil
+}
+
+// Delete removes a class, returning ErrNotFound if it does not exist
+func (r *ClassRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.Collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error deleting class %s", id.Hex())
+		return fmt.Errorf("failed to delete class: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Deleted class with ID: %v", id)
+	return nil
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestClassFilterQuery(t *testing.T) {
+	from := models.CustomDate(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+	to := models.CustomDate(time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		filter   ClassFilter
+		expected bson.M
+	}{
+		{"empty filter matches everything", ClassFilter{}, bson.M{}},
+		{
+			"name is an escaped case-insensitive substring",
+			ClassFilter{Name: "yoga+"},
+			bson.M{"name": primitive.Regex{Pattern: `yoga\+`, Options: "i"}},
+		},
+		{
+			"window matches overlapping classes",
+			ClassFilter{From: &from, To: &to},
+			bson.M{"end_date": bson.M{"$gte": from}, "start_date": bson.M{"$lte": to}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.query())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// copyBatchSize is the number of documents written per bulk write when copying a collection
+const copyBatchSize = 500
+
+// legacyCollection is where a collection lived before all of them shared one database
+type legacyCollection struct {
+	database   string
+	collection string
+	target     func(Collections) string
+}
+
+// legacyCollections lists the collections of the classes, bookings and members databases
+var legacyCollections = []legacyCollection{
+	{"classes", "classes", func(c Collections) string { return c.Classes }},
+	{"bookings", "bookings", func(c Collections) string { return c.Bookings }},
+	{"bookings", "class_occurrences", func(c Collections) string { return c.Occurrences }},
+	{"bookings", "waitlist", func(c Collections) string { return c.Waitlist }},
+	{"members", "members", func(c Collections) string { return c.Members }},
+}
+
+// DatabaseMigrationReport summarises a MigrateLegacyDatabases run, per "database.collection" source
+type DatabaseMigrationReport struct {
+	Copied  map[string]int // Documents copied to the application database
+	Skipped map[string]int // Documents already in the application database, left as they are
+}
+
+// MigrateLegacyDatabases copies the documents of the legacy classes, bookings and members
+// databases into the collections of the application database. Documents whose _id is
+// already there are kept, so running it again is safe and never overwrites newer data.
+// The legacy databases are left untouched, drop them once the copy is checked.
+func MigrateLegacyDatabases(ctx context.Context, m *MongoRepository) (*DatabaseMigrationReport, error) {
+	report := &DatabaseMigrationReport{Copied: map[string]int{}, Skipped: map[string]int{}}
+	for _, legacy := range legacyCollections {
+		source := m.Client.Database(legacy.database).Collection(legacy.collection)
+		target := m.Collection(legacy.target(m.Collections))
+		name := legacy.database + "." + legacy.collection
+		if source.Database().Name() == target.Database().Name() && source.Name() == target.Name() {
+			log.Info().Ctx(ctx).Msgf("Skipping %s, it already is the %s collection", name, target.Name())
+			continue
+		}
+
+		copied, skipped, err := copyCollection(ctx, source, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+		report.Copied[name], report.Skipped[name] = copied, skipped
+		log.Info().Ctx(ctx).Msgf("Copied %d documents from %s to %s.%s, %d were already there",
+			copied, name, target.Database().Name(), target.Name(), skipped)
+	}
+	return report, nil
+}
+
+// copyCollection inserts the documents of source that target does not hold yet
+func copyCollection(ctx context.Context, source, target *mongo.Collection) (copied, skipped int, err error) {
+	cursor, err := source.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var batch []mongo.WriteModel
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		res, err := target.BulkWrite(ctx, batch, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return err
+		}
+		copied += int(res.UpsertedCount)
+		skipped += int(res.MatchedCount)
+		batch = batch[:0]
+		return nil
+	}
+
+	for cursor.Next(ctx) {
+		doc := cursor.Current
+		fields := bson.D{}
+		elems, err := doc.Elements()
+		if err != nil {
+			return copied, skipped, err
+		}
+		for _, elem := range elems {
+			if elem.Key() != "_id" {
+				fields = append(fields, bson.E{Key: elem.Key(), Value: elem.Value()})
+			}
+		}
+		if len(fields) == 0 {
+			// $setOnInsert cannot be empty, a document holding only its _id sets it again
+			fields = append(fields, bson.E{Key: "_id", Value: doc.Lookup("_id")})
+		}
+		// An upsert that only sets fields on insert leaves an existing document as it is
+		batch = append(batch, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc.Lookup("_id")}).
+			SetUpdate(bson.M{"$setOnInsert": fields}).
+			SetUpsert(true))
+		if len(batch) == copyBatchSize {
+			if err := flush(); err != nil {
+				return copied, skipped, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return copied, skipped, err
+	}
+	return copied, skipped, flush()
+}
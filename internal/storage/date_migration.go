@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DateMigrationReport summarises a MigrateLegacyDates run
+type DateMigrationReport struct {
+	Converted     int      // Documents rewritten with BSON dates
+	Unrecoverable []string // "collection/id/field" of dates that were lost and must be fixed by hand
+}
+
+// MigrateLegacyDates rewrites dates stored before CustomDate had a BSON encoding.
+// Those were written as empty documents, so the date itself is lost: only dates
+// stored as "YYYY-MM-DD" strings and booking dates that can be derived from the
+// session start are converted. Everything else is left untouched and reported.
+// Running it again is safe, converted documents no longer match.
+func MigrateLegacyDates(ctx context.Context, mr *MongoRepository) (*DateMigrationReport, error) {
+	m := &dateMigration{classes: mr.Collection(mr.Collections.Classes), report: &DateMigrationReport{}}
+
+	if err := m.migrate(ctx, m.classes, []string{"start_date", "end_date", "recurrence.exceptions"}, nil); err != nil {
+		return nil, err
+	}
+	if err := m.migrate(ctx, mr.Collection(mr.Collections.Bookings), []string{"date"}, m.bookingDate); err != nil {
+		return nil, err
+	}
+	if err := m.migrate(ctx, mr.Collection(mr.Collections.Waitlist), []string{"date"}, nil); err != nil {
+		return nil, err
+	}
+	return m.report, nil
+}
+
+// dateMigration holds the state of a MigrateLegacyDates run
+type dateMigration struct {
+	classes *mongo.Collection
+	report  *DateMigrationReport
+}
+
+// recoverFunc derives a lost date from the rest of the document
+type recoverFunc func(ctx context.Context, doc bson.Raw) (models.CustomDate, bool)
+
+// migrate converts the legacy date fields of every matching document in the collection
+func (m *dateMigration) migrate(ctx context.Context, collection *mongo.Collection, fields []string, derive recoverFunc) error {
+	var legacy bson.A
+	for _, field := range fields {
+		legacy = append(legacy, bson.M{field: bson.M{"$type": bson.A{"object", "string"}}})
+	}
+	cursor, err := collection.Find(ctx, bson.M{"$or": legacy})
+	if err != nil {
+		return fmt.Errorf("failed to find legacy dates in %s: %w", collection.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		doc := cursor.Current
+		id := doc.Lookup("_id")
+		set := bson.M{}
+		for _, field := range fields {
+			value, changed, lost := convertLegacyDate(doc.Lookup(strings.Split(field, ".")...))
+			if lost && derive != nil {
+				if date, ok := derive(ctx, doc); ok {
+					value, changed, lost = date, true, false
+				}
+			}
+			if lost {
+				m.report.Unrecoverable = append(m.report.Unrecoverable, fmt.Sprintf("%s/%s/%s", collection.Name(), idString(id), field))
+			}
+			if changed {
+				set[field] = value
+			}
+		}
+		if len(set) == 0 {
+			continue
+		}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set}); err != nil {
+			return fmt.Errorf("failed to migrate %s %s: %w", collection.Name(), idString(id), err)
+		}
+		m.report.Converted++
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", collection.Name(), err)
+	}
+	log.Info().Ctx(ctx).Msgf("Migrated legacy dates in %s", collection.Name())
+	return nil
+}
+
+// bookingDate derives the date of a booking from its session start and the class timezone
+func (m *dateMigration) bookingDate(ctx context.Context, doc bson.Raw) (models.CustomDate, bool) {
+	startsAt, ok := doc.Lookup("starts_at").TimeOK()
+	if !ok {
+		return models.CustomDate{}, false
+	}
+	classID, ok := doc.Lookup("class_id").ObjectIDOK()
+	if !ok {
+		return models.CustomDate{}, false
+	}
+	var class struct {
+		Timezone string `bson:"timezone"`
+	}
+	if err := m.classes.FindOne(ctx, bson.M{"_id": classID}).Decode(&class); err != nil {
+		return models.CustomDate{}, false
+	}
+	loc, err := time.LoadLocation(class.Timezone)
+	if class.Timezone == "" || err != nil {
+		return models.CustomDate{}, false
+	}
+	local := startsAt.In(loc)
+	return models.CustomDate(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)), true
+}
+
+// convertLegacyDate returns the value a stored date field should be rewritten to.
+// changed is false when the field is already a BSON date, null or missing, lost is
+// true when the value holds no date, like the empty documents of the old encoding.
+// In arrays the recoverable elements are converted and lost ones kept as they are.
+func convertLegacyDate(value bson.RawValue) (converted interface{}, changed bool, lost bool) {
+	switch value.Type {
+	case bson.TypeString:
+		parsed, err := time.Parse("2006-01-02", value.StringValue())
+		if err != nil {
+			return nil, false, true
+		}
+		return models.CustomDate(parsed), true, false
+	case bson.TypeEmbeddedDocument:
+		return nil, false, true
+	case bson.TypeArray:
+		elems, err := value.Array().Values()
+		if err != nil {
+			return nil, false, true
+		}
+		dates := make(bson.A, 0, len(elems))
+		for _, elem := range elems {
+			date, elemChanged, elemLost := convertLegacyDate(elem)
+			changed, lost = changed || elemChanged, lost || elemLost
+			if elemChanged {
+				dates = append(dates, date)
+			} else {
+				dates = append(dates, elem)
+			}
+		}
+		return dates, changed, lost
+	default:
+		return nil, false, false
+	}
+}
+
+// idString formats a document ID for logs and reports
+func idString(id bson.RawValue) string {
+	if oid, ok := id.ObjectIDOK(); ok {
+		return oid.Hex()
+	}
+	return id.String()
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestConvertLegacyDate(t *testing.T) {
+	lookup := func(value interface{}) bson.RawValue {
+		data, _ := bson.Marshal(bson.M{"v": value})
+		return bson.Raw(data).Lookup("v")
+	}
+	march10 := models.CustomDate(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		value   bson.RawValue
+		changed bool
+		lost    bool
+	}{
+		{"BSON date is kept", lookup(march10), false, false},
+		{"null is kept", lookup(nil), false, false},
+		{"missing field is kept", bson.RawValue{}, false, false},
+		{"date string is converted", lookup("2025-03-10"), true, false},
+		{"invalid string is lost", lookup("10/03/2025"), false, true},
+		{"empty document is lost", lookup(bson.M{}), false, true},
+		{"array converts what it can", lookup(bson.A{"2025-03-10", bson.M{}}), true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, changed, lost := convertLegacyDate(tt.value)
+
+			assert.Equal(t, tt.changed, changed)
+			assert.Equal(t, tt.lost, lost)
+			if tt.changed && tt.value.Type == bson.TypeString {
+				assert.Equal(t, march10, converted)
+			}
+		})
+	}
+
+	t.Run("converted arrays encode as BSON dates", func(t *testing.T) {
+		converted, _, _ := convertLegacyDate(lookup(bson.A{"2025-03-10", march10}))
+
+		data, err := bson.Marshal(bson.M{"v": converted})
+		assert.NoError(t, err)
+		values, _ := bson.Raw(data).Lookup("v").Array().Values()
+		for _, v := range values {
+			assert.Equal(t, bson.TypeDateTime, v.Type)
+		}
+	})
+}
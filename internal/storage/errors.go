@@ -0,0 +1,21 @@
+package storage
+
+import "errors"
+
+// ErrNotFound is returned when a requested document does not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrClassFull is returned when a class occurrence has no remaining capacity
+var ErrClassFull = errors.New("class is fully booked for this date")
+
+// ErrAlreadyBooked is returned when a member already has an active booking for the class on that date
+var ErrAlreadyBooked = errors.New("member already has an active booking for this class on this date")
+
+// ErrAlreadyWaitlisted is returned when a member is already waiting for the class on that date
+var ErrAlreadyWaitlisted = errors.New("member is already on the waitlist for this class on this date")
+
+// ErrAlreadyCancelled is returned when cancelling a booking that is already cancelled
+var ErrAlreadyCancelled = errors.New("booking is already cancelled")
+
+// ErrEmailTaken is returned when a member email is already used by another member
+var ErrEmailTaken = errors.New("email is already used by another member")
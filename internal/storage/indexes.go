@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// codeNamespaceNotFound is the server error of listing the indexes of a collection that does not exist yet
+const codeNamespaceNotFound = 26
+
+// indexSpec describes an index the repositories rely on
+type indexSpec struct {
+	collection func(Collections) string
+	name       string
+	keys       bson.D
+	unique     bool
+	partial    bson.D             // Only documents matching it are indexed
+	collation  *options.Collation // Must match the collation of the queries the index serves
+}
+
+// indexes lists the indexes EnsureIndexes keeps in place
+var indexes = []indexSpec{
+	{
+		collection: func(c Collections) string { return c.Members },
+		name:       "email_unique",
+		keys:       bson.D{{Key: "email", Value: 1}},
+		unique:     true,
+	},
+	// At most one active booking per member, class and date. Cancelled bookings and
+	// bookings made before members existed are left out of the index.
+	{
+		collection: func(c Collections) string { return c.Bookings },
+		name:       "active_booking_unique",
+		keys:       bson.D{{Key: "member_id", Value: 1}, {Key: "class_id", Value: 1}, {Key: "date", Value: 1}},
+		unique:     true,
+		partial: bson.D{
+			{Key: "status", Value: models.BookingStatusActive},
+			{Key: "member_id", Value: bson.D{{Key: "$exists", Value: true}}},
+		},
+	},
+	// Serves the front desk question "who is booked into this class today?"
+	{
+		collection: func(c Collections) string { return c.Bookings },
+		name:       "class_date",
+		keys:       bson.D{{Key: "class_id", Value: 1}, {Key: "date", Value: 1}},
+	},
+	// Serves sorting classes by name, with the case-insensitive collation Page.apply sorts with
+	{
+		collection: func(c Collections) string { return c.Classes },
+		name:       "name",
+		keys:       bson.D{{Key: "name", Value: 1}},
+		collation:  &options.Collation{Locale: "en", Strength: 2},
+	},
+	// Serves the classes running in a date window
+	{
+		collection: func(c Collections) string { return c.Classes },
+		name:       "date_range",
+		keys:       bson.D{{Key: "start_date", Value: 1}, {Key: "end_date", Value: 1}},
+	},
+	// A member waits at most once for a class occurrence
+	{
+		collection: func(c Collections) string { return c.Waitlist },
+		name:       "waitlist_member_unique",
+		keys:       bson.D{{Key: "member_id", Value: 1}, {Key: "class_id", Value: 1}, {Key: "date", Value: 1}},
+		unique:     true,
+		partial:    bson.D{{Key: "status", Value: models.WaitlistStatusWaiting}},
+	},
+	// Serves the waiting queue of a class occurrence
+	{
+		collection: func(c Collections) string { return c.Waitlist },
+		name:       "waitlist_queue",
+		keys:       bson.D{{Key: "class_id", Value: 1}, {Key: "date", Value: 1}, {Key: "status", Value: 1}, {Key: "_id", Value: 1}},
+	},
+}
+
+// model returns the index model creating the index
+func (s indexSpec) model() mongo.IndexModel {
+	opts := options.Index().SetName(s.name)
+	if s.unique {
+		opts.SetUnique(true)
+	}
+	if s.partial != nil {
+		opts.SetPartialFilterExpression(s.partial)
+	}
+	if s.collation != nil {
+		opts.SetCollation(s.collation)
+	}
+	return mongo.IndexModel{Keys: s.keys, Options: opts}
+}
+
+// matches reports whether an index listed by the server has the definition of the spec
+func (s indexSpec) matches(existing bson.Raw) bool {
+	if !rawEqual(existing.Lookup("key"), s.keys, true) {
+		return false
+	}
+	unique, _ := existing.Lookup("unique").BooleanOK()
+	if unique != s.unique {
+		return false
+	}
+	// Partial filters used to be written from maps, their fields may be stored in any order
+	partial := existing.Lookup("partialFilterExpression")
+	if (partial.Type != 0 || s.partial != nil) && !rawEqual(partial, s.partial, false) {
+		return false
+	}
+	// The server fills in every collation option, compare the ones the specs set
+	locale, _ := existing.Lookup("collation", "locale").StringValueOK()
+	if s.collation == nil {
+		return locale == "" || locale == "simple"
+	}
+	strength, _ := existing.Lookup("collation", "strength").AsInt64OK()
+	return locale == s.collation.Locale && strength == int64(s.collation.Strength)
+}
+
+// rawEqual reports whether a stored document equals doc, in field order when ordered is set.
+// Numbers compare by value, since the server may return index key directions as doubles.
+func rawEqual(stored bson.RawValue, doc bson.D, ordered bool) bool {
+	storedDoc, ok := stored.DocumentOK()
+	if !ok {
+		return false
+	}
+	elems, err := storedDoc.Elements()
+	if err != nil || len(elems) != len(doc) {
+		return false
+	}
+	for i, e := range doc {
+		value := storedDoc.Lookup(e.Key)
+		if ordered {
+			if elems[i].Key() != e.Key {
+				return false
+			}
+			value = elems[i].Value()
+		}
+		if !valueEqual(value, e.Value, ordered) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEqual reports whether a stored value equals want
+func valueEqual(stored bson.RawValue, want interface{}, ordered bool) bool {
+	if sub, ok := want.(bson.D); ok {
+		return rawEqual(stored, sub, ordered)
+	}
+	kind, data, err := bson.MarshalValue(want)
+	if err != nil {
+		return false
+	}
+	wanted := bson.RawValue{Type: kind, Value: data}
+	if x, ok := number(stored); ok {
+		y, ok := number(wanted)
+		return ok && x == y
+	}
+	return stored.Equal(wanted)
+}
+
+// number returns the value of a BSON number of any type
+func number(v bson.RawValue) (float64, bool) {
+	switch v.Type {
+	case bson.TypeDouble:
+		return v.Double(), true
+	case bson.TypeInt32:
+		return float64(v.Int32()), true
+	case bson.TypeInt64:
+		return float64(v.Int64()), true
+	}
+	return 0, false
+}
+
+// IndexReport lists what EnsureIndexes did, each index named "collection.index"
+type IndexReport struct {
+	Created   []string
+	Rebuilt   []string // Existed with another definition, dropped and created again
+	Unchanged []string
+}
+
+// EnsureIndexes creates the indexes the repositories rely on for correctness and speed.
+// Indexes already in place are left alone, so it is safe to call on every start. An
+// index whose definition changed is dropped and created again.
+func EnsureIndexes(ctx context.Context, m *MongoRepository) (*IndexReport, error) {
+	report := &IndexReport{}
+	for _, spec := range indexes {
+		collection := m.Collection(spec.collection(m.Collections))
+		name := collection.Name() + "." + spec.name
+
+		existing, err := findIndex(ctx, collection, spec.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the indexes of %s: %w", collection.Name(), err)
+		}
+		if existing != nil && spec.matches(existing) {
+			report.Unchanged = append(report.Unchanged, name)
+			continue
+		}
+		if existing != nil {
+			if _, err := collection.Indexes().DropOne(ctx, spec.name); err != nil {
+				return nil, fmt.Errorf("failed to drop index %s: %w", name, err)
+			}
+		}
+
+		if _, err := collection.Indexes().CreateOne(ctx, spec.model()); err != nil {
+			if spec.unique && mongo.IsDuplicateKeyError(err) {
+				return nil, fmt.Errorf("failed to create unique index %s, remove the duplicates first: %w", name, err)
+			}
+			return nil, fmt.Errorf("failed to create index %s: %w", name, err)
+		}
+		if existing != nil {
+			log.Info().Ctx(ctx).Msgf("Rebuilt index %s, its definition changed", name)
+			report.Rebuilt = append(report.Rebuilt, name)
+		} else {
+			log.Info().Ctx(ctx).Msgf("Created index %s", name)
+			report.Created = append(report.Created, name)
+		}
+	}
+
+	log.Info().Ctx(ctx).Msgf("Indexes are in place: %d created, %d rebuilt, %d unchanged",
+		len(report.Created), len(report.Rebuilt), len(report.Unchanged))
+	return report, nil
+}
+
+// findIndex returns the definition of the named index of a collection, nil if there is none
+func findIndex(ctx context.Context, collection *mongo.Collection, name string) (bson.Raw, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == codeNamespaceNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if indexName, _ := cursor.Current.Lookup("name").StringValueOK(); indexName == name {
+			return append(bson.Raw(nil), cursor.Current...), nil
+		}
+	}
+	return nil, cursor.Err()
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestIndexSpecMatches(t *testing.T) {
+	listed := func(doc bson.M) bson.Raw {
+		data, err := bson.Marshal(doc)
+		assert.NoError(t, err)
+		return data
+	}
+	spec := indexSpec{
+		name:    "active_booking_unique",
+		keys:    bson.D{{Key: "member_id", Value: 1}, {Key: "class_id", Value: 1}},
+		unique:  true,
+		partial: bson.D{{Key: "status", Value: "active"}, {Key: "member_id", Value: bson.D{{Key: "$exists", Value: true}}}},
+	}
+	keys := bson.D{{Key: "member_id", Value: 1.0}, {Key: "class_id", Value: int32(1)}}
+	partial := bson.D{{Key: "member_id", Value: bson.M{"$exists": true}}, {Key: "status", Value: "active"}}
+	collated := indexSpec{name: "name", keys: bson.D{{Key: "name", Value: 1}}, collation: &options.Collation{Locale: "en", Strength: 2}}
+
+	tests := []struct {
+		name     string
+		spec     indexSpec
+		existing bson.M
+		want     bool
+	}{
+		{"same definition", spec, bson.M{"key": keys, "unique": true, "partialFilterExpression": partial}, true},
+		{"keys in another order", spec, bson.M{"key": bson.D{keys[1], keys[0]}, "unique": true, "partialFilterExpression": partial}, false},
+		{"another key direction", spec, bson.M{"key": bson.D{keys[0], {Key: "class_id", Value: -1}}, "unique": true, "partialFilterExpression": partial}, false},
+		{"not unique", spec, bson.M{"key": keys, "partialFilterExpression": partial}, false},
+		{"no partial filter", spec, bson.M{"key": keys, "unique": true}, false},
+		{"another partial filter", spec, bson.M{"key": keys, "unique": true, "partialFilterExpression": bson.M{"status": "cancelled"}}, false},
+		{"unexpected partial filter", indexSpec{keys: spec.keys}, bson.M{"key": keys, "partialFilterExpression": partial}, false},
+		{"same collation", collated, bson.M{"key": bson.M{"name": 1}, "collation": bson.M{"locale": "en", "strength": 2, "caseLevel": false}}, true},
+		{"another strength", collated, bson.M{"key": bson.M{"name": 1}, "collation": bson.M{"locale": "en", "strength": 3}}, false},
+		{"missing collation", collated, bson.M{"key": bson.M{"name": 1}}, false},
+		{"unexpected collation", indexSpec{keys: collated.keys}, bson.M{"key": bson.M{"name": 1}, "collation": bson.M{"locale": "en", "strength": 2}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.spec.matches(listed(tt.existing)))
+		})
+	}
+}
+
+func TestIndexesAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, spec := range indexes {
+		name := spec.collection(DefaultCollections) + "." + spec.name
+		assert.False(t, seen[name], "index %s is declared twice", name)
+		seen[name] = true
+	}
+}
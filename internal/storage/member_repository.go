@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemberRepositoryInterface defines the contract for MemberRepository
+type MemberRepositoryInterface interface {
+	Create(ctx context.Context, member *models.Member) (primitive.ObjectID, error)
+	GetAll(ctx context.Context) ([]models.Member, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Member, error)
+	Update(ctx context.Context, member *models.Member) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// MemberRepository struct for MongoDB
+type MemberRepository struct {
+	Collection *mongo.Collection
+}
+
+// NewMemberRepository initializes a MemberRepository with MongoDB collection.
+// Email uniqueness relies on the index created by EnsureIndexes.
+func NewMemberRepository(collection *mongo.Collection) MemberRepositoryInterface {
+	return &MemberRepository{Collection: collection}
+}
+
+// Create inserts a new member, returning ErrEmailTaken if the email is in use
+func (r *MemberRepository) Create(ctx context.Context, member *models.Member) (primitive.ObjectID, error) {
+	res, err := r.Collection.InsertOne(ctx, member)
+	if mongo.IsDuplicateKeyError(err) {
+		return primitive.NilObjectID, ErrEmailTaken
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting member")
+		return primitive.NilObjectID, fmt.Errorf("failed to insert member: %w", err)
+	}
+	log.Info().Ctx(ctx).Msgf("Inserted member with ID: %v", res.InsertedID)
+	return res.InsertedID.(primitive.ObjectID), nil
+}
+
+// GetAll retrieves all members
+func (r *MemberRepository) GetAll(ctx context.Context) ([]models.Member, error) {
+	cursor, err := r.Collection.Find(ctx, bson.M{})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding members")
+		return nil, fmt.Errorf("failed to find members: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var members []models.Member
+	if err := cursor.All(ctx, &members); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error decoding members")
+		return nil, fmt.Errorf("failed to decode members: %w", err)
+	}
+	return members, nil
+}
+
+// GetByID retrieves a single member by its ID, returning ErrNotFound if it does not exist
+func (r *MemberRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Member, error) {
+	var member models.Member
+	err := r.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&member)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding member %s", id.Hex())
+		return nil, fmt.Errorf("failed to find member: %w", err)
+	}
+	return &member, nil
+}
+
+// Update replaces a stored member, returning ErrNotFound if it does not exist or
+// ErrEmailTaken if the new email is used by another member
+func (r *MemberRepository) Update(ctx context.Context, member *models.Member) error {
+	res, err := r.Collection.ReplaceOne(ctx, bson.M{"_id": member.ID}, member)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrEmailTaken
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error updating member %s", member.ID.Hex())
+		return fmt.Errorf("failed to update member: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Updated member with ID: %v", member.ID)
+	return nil
+}
+
+// Delete removes a member, returning ErrNotFound if it does not exist
+func (r *MemberRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.Collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error deleting member %s", id.Hex())
+		return fmt.Errorf("failed to delete member: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Deleted member with ID: %v", id)
+	return nil
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryStore keeps every collection in process memory, for demos and tests that run without
+// MongoDB. It is safe for concurrent use and its data is lost when the process exits.
+// The memory repositories share one store, so a booking and its class occurrence counter
+// change together under the same lock.
+type MemoryStore struct {
+	mu          sync.RWMutex
+	classes     map[primitive.ObjectID]models.Class
+	bookings    map[primitive.ObjectID]models.Booking
+	occurrences map[string]*memoryOccurrence // Keyed by occurrenceKey
+	waitlist    map[primitive.ObjectID]models.WaitlistEntry
+	members     map[primitive.ObjectID]models.Member
+}
+
+// memoryOccurrence is the in-memory class_occurrences counter of a class on a date
+type memoryOccurrence struct {
+	ClassID primitive.ObjectID
+	Date    models.CustomDate
+	Booked  int
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		classes:     map[primitive.ObjectID]models.Class{},
+		bookings:    map[primitive.ObjectID]models.Booking{},
+		occurrences: map[string]*memoryOccurrence{},
+		waitlist:    map[primitive.ObjectID]models.WaitlistEntry{},
+		members:     map[primitive.ObjectID]models.Member{},
+	}
+}
+
+// clone deep-copies a document through its BSON encoding, so stored documents never share
+// pointers with the caller and round-trip exactly as they would through MongoDB
+func clone[T any](doc T) T {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		panic(fmt.Sprintf("memory store: failed to encode %T: %v", doc, err))
+	}
+	var copied T
+	if err := bson.Unmarshal(data, &copied); err != nil {
+		panic(fmt.Sprintf("memory store: failed to decode %T: %v", doc, err))
+	}
+	return copied
+}
+
+// compareIDs orders ObjectIDs like MongoDB does
+func compareIDs(a, b primitive.ObjectID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// sortedByID returns the values of a collection in _id order
+func sortedByID[T any](docs map[primitive.ObjectID]T) []T {
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for id := range docs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return compareIDs(ids[i], ids[j]) < 0 })
+
+	sorted := make([]T, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, clone(docs[id]))
+	}
+	return sorted
+}
+
+// compareValues orders two BSON values of the sortable field types. Strings compare
+// case-insensitively, like the collation Page.apply sets on MongoDB.
+func compareValues(a, b bson.RawValue) int {
+	switch {
+	case a.Type == bson.TypeString && b.Type == bson.TypeString:
+		return strings.Compare(strings.ToLower(a.StringValue()), strings.ToLower(b.StringValue()))
+	case a.Type == bson.TypeDateTime && b.Type == bson.TypeDateTime:
+		return compareInts(a.DateTime(), b.DateTime())
+	}
+	if x, ok := a.AsInt64OK(); ok {
+		if y, ok := b.AsInt64OK(); ok {
+			return compareInts(x, y)
+		}
+	}
+	// Mixed or unsortable types, order by type like MongoDB's BSON comparison roughly does
+	return compareInts(int64(a.Type), int64(b.Type))
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// applyPage sorts documents already in _id order the way Page.apply sorts them on MongoDB
+// and returns the documents of the page
+func applyPage[T any](docs []T, page Page) []T {
+	if page.Sort.Field != "" {
+		keys := make(map[int]bson.RawValue, len(docs))
+		for i, doc := range docs {
+			data, _ := bson.Marshal(doc)
+			keys[i] = bson.Raw(data).Lookup(page.Sort.Field)
+		}
+		order := make([]int, len(docs))
+		for i := range order {
+			order[i] = i
+		}
+		// Stable on the _id order of docs, which breaks ties like the _id sort key does
+		sort.SliceStable(order, func(i, j int) bool {
+			c := compareValues(keys[order[i]], keys[order[j]])
+			if page.Sort.Desc {
+				c = -c
+			}
+			return c < 0
+		})
+
+		sorted := make([]T, 0, len(docs))
+		for _, i := range order {
+			if !page.After.IsZero() && !afterKey(keys[i], idOf(docs[i]), page) {
+				continue
+			}
+			sorted = append(sorted, docs[i])
+		}
+		docs = sorted
+	} else if !page.After.IsZero() {
+		var after []T
+		for _, doc := range docs {
+			if compareIDs(idOf(doc), page.After) > 0 {
+				after = append(after, doc)
+			}
+		}
+		docs = after
+	}
+
+	if page.Limit > 0 && len(docs) > page.Limit {
+		docs = docs[:page.Limit]
+	}
+	return docs
+}
+
+// afterKey reports whether a document with the given sort key and ID comes after the page's After document
+func afterKey(key bson.RawValue, id primitive.ObjectID, page Page) bool {
+	c := compareValues(key, page.AfterKey)
+	if page.Sort.Desc {
+		c = -c
+	}
+	return c > 0 || (c == 0 && compareIDs(id, page.After) > 0)
+}
+
+// idOf returns the _id of a document
+func idOf(doc interface{}) primitive.ObjectID {
+	data, _ := bson.Marshal(doc)
+	id, _ := bson.Raw(data).Lookup("_id").ObjectIDOK()
+	return id
+}
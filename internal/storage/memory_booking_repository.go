@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryBookingRepository is the BookingRepositoryInterface of a MemoryStore
+type MemoryBookingRepository struct {
+	Store *MemoryStore
+}
+
+// NewMemoryBookingRepository initializes a BookingRepository backed by a MemoryStore
+func NewMemoryBookingRepository(store *MemoryStore) BookingRepositoryInterface {
+	return &MemoryBookingRepository{Store: store}
+}
+
+// matches is the in-memory equivalent of query
+func (f BookingFilter) matches(booking models.Booking) bool {
+	switch {
+	case !f.ClassID.IsZero() && booking.ClassID != f.ClassID,
+		!f.MemberID.IsZero() && booking.MemberID != f.MemberID,
+		f.MemberName != "" && !strings.EqualFold(booking.MemberName, f.MemberName),
+		f.Date != nil && booking.Date.String() != f.Date.String(),
+		f.From != nil && booking.Date.String() < f.From.String(),
+		f.To != nil && booking.Date.String() > f.To.String(),
+		!f.matchesStatus(booking.Status):
+		return false
+	}
+	return true
+}
+
+// matchesStatus is the in-memory equivalent of the status condition of query
+func (f BookingFilter) matchesStatus(status string) bool {
+	switch f.Status {
+	case "":
+		return true
+	case models.BookingStatusActive:
+		return status != models.BookingStatusCancelled
+	default:
+		return status == f.Status
+	}
+}
+
+// hasActiveBooking reports whether the member of the booking already has an active
+// booking for the same class and date. The caller holds the store lock.
+func (r *MemoryBookingRepository) hasActiveBooking(booking *models.Booking) bool {
+	if booking.MemberID.IsZero() {
+		return false
+	}
+	for _, b := range r.Store.bookings {
+		if b.MemberID == booking.MemberID && b.ClassID == booking.ClassID &&
+			b.Date.String() == booking.Date.String() && b.Status == models.BookingStatusActive {
+			return true
+		}
+	}
+	return false
+}
+
+// Create reserves a spot on the class occurrence and stores the booking
+func (r *MemoryBookingRepository) Create(ctx context.Context, booking *models.Booking, capacity int) (primitive.ObjectID, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	key := occurrenceKey(booking.ClassID, booking.Date)
+	occurrence := r.Store.occurrences[key]
+	if capacity <= 0 || (occurrence != nil && occurrence.Booked >= capacity) {
+		// A full class is the less useful answer when the member is already booked on it
+		if r.hasActiveBooking(booking) {
+			return primitive.NilObjectID, ErrAlreadyBooked
+		}
+		return primitive.NilObjectID, ErrClassFull
+	}
+	if booking.Status == models.BookingStatusActive && r.hasActiveBooking(booking) {
+		return primitive.NilObjectID, ErrAlreadyBooked
+	}
+
+	stored := clone(*booking)
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	r.Store.bookings[stored.ID] = stored
+	if occurrence == nil {
+		occurrence = &memoryOccurrence{ClassID: booking.ClassID, Date: booking.Date}
+		r.Store.occurrences[key] = occurrence
+	}
+	occurrence.Booked++
+	return stored.ID, nil
+}
+
+// GetAll retrieves a page of the bookings matching the filter
+func (r *MemoryBookingRepository) GetAll(ctx context.Context, filter BookingFilter, page Page) ([]models.Booking, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var bookings []models.Booking
+	for _, booking := range sortedByID(r.Store.bookings) {
+		if filter.matches(booking) {
+			bookings = append(bookings, booking)
+		}
+	}
+	return applyPage(bookings, page), nil
+}
+
+// GetByID retrieves a single booking by its ID, returning ErrNotFound if it does not exist
+func (r *MemoryBookingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Booking, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	booking, ok := r.Store.bookings[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	booking = clone(booking)
+	return &booking, nil
+}
+
+// CountBooked reads the occurrence counters of a class between two dates, inclusive
+func (r *MemoryBookingRepository) CountBooked(ctx context.Context, classID primitive.ObjectID, from, to models.CustomDate) (map[string]int, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	booked := map[string]int{}
+	for _, o := range r.Store.occurrences {
+		date := o.Date.String()
+		if o.ClassID == classID && date >= from.String() && date <= to.String() {
+			booked[date] = o.Booked
+		}
+	}
+	return booked, nil
+}
+
+// GetActiveByClass retrieves the active bookings of a class dated from on, in creation order
+func (r *MemoryBookingRepository) GetActiveByClass(ctx context.Context, classID primitive.ObjectID, from models.CustomDate) ([]models.Booking, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var bookings []models.Booking
+	for _, booking := range sortedByID(r.Store.bookings) {
+		if booking.ClassID == classID && booking.Date.String() >= from.String() && booking.Status != models.BookingStatusCancelled {
+			bookings = append(bookings, booking)
+		}
+	}
+	return bookings, nil
+}
+
+// Cancel marks a booking as cancelled and releases its spot
+func (r *MemoryBookingRepository) Cancel(ctx context.Context, id primitive.ObjectID, reason string) (*models.Booking, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	booking, ok := r.Store.bookings[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if booking.Status == models.BookingStatusCancelled {
+		return nil, ErrAlreadyCancelled
+	}
+
+	cancelledAt := time.Now().UTC()
+	booking.Status = models.BookingStatusCancelled
+	booking.CancelledAt = &cancelledAt
+	booking.CancellationReason = reason
+	booking = clone(booking)
+	r.Store.bookings[id] = booking
+
+	if o := r.Store.occurrences[occurrenceKey(booking.ClassID, booking.Date)]; o != nil && o.Booked > 0 {
+		o.Booked--
+	}
+	booking = clone(booking)
+	return &booking, nil
+}
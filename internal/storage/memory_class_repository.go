@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryClassRepository is the ClassRepositoryInterface of a MemoryStore
+type MemoryClassRepository struct {
+	Store *MemoryStore
+}
+
+// NewMemoryClassRepository initializes a ClassRepository backed by a MemoryStore
+func NewMemoryClassRepository(store *MemoryStore) ClassRepositoryInterface {
+	return &MemoryClassRepository{Store: store}
+}
+
+// matches is the in-memory equivalent of query
+func (f ClassFilter) matches(class models.Class) bool {
+	if f.Name != "" && !strings.Contains(strings.ToLower(class.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+	if f.From != nil && class.EndDate.String() < f.From.String() {
+		return false
+	}
+	if f.To != nil && class.StartDate.String() > f.To.String() {
+		return false
+	}
+	return true
+}
+
+// Create stores a new class
+func (r *MemoryClassRepository) Create(ctx context.Context, class *models.Class) (primitive.ObjectID, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	stored := clone(*class)
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	r.Store.classes[stored.ID] = stored
+	return stored.ID, nil
+}
+
+// GetAll retrieves a page of the classes matching the filter
+func (r *MemoryClassRepository) GetAll(ctx context.Context, filter ClassFilter, page Page) ([]models.Class, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var classes []models.Class
+	for _, class := range sortedByID(r.Store.classes) {
+		if filter.matches(class) {
+			classes = append(classes, class)
+		}
+	}
+	return applyPage(classes, page), nil
+}
+
+// GetByID retrieves a single class by its ID, returning ErrNotFound if it does not exist
+func (r *MemoryClassRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Class, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	class, ok := r.Store.classes[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	class = clone(class)
+	return &class, nil
+}
+
+// Update replaces a stored class, returning ErrNotFound if it does not exist
+func (r *MemoryClassRepository) Update(ctx context.Context, class *models.Class) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if _, ok := r.Store.classes[class.ID]; !ok {
+		return ErrNotFound
+	}
+	r.Store.classes[class.ID] = clone(*class)
+	return nil
+}
+
+// Delete removes a class, returning ErrNotFound if it does not exist
+func (r *MemoryClassRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if _, ok := r.Store.classes[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.Store.classes, id)
+	return nil
+}
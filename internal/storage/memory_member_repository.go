@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryMemberRepository is the MemberRepositoryInterface of a MemoryStore
+type MemoryMemberRepository struct {
+	Store *MemoryStore
+}
+
+// NewMemoryMemberRepository initializes a MemberRepository backed by a MemoryStore
+func NewMemoryMemberRepository(store *MemoryStore) MemberRepositoryInterface {
+	return &MemoryMemberRepository{Store: store}
+}
+
+// emailTaken reports whether a member other than id uses the email, like the email_unique
+// index does on MongoDB. The caller holds the store lock.
+func (r *MemoryMemberRepository) emailTaken(email string, id primitive.ObjectID) bool {
+	for _, m := range r.Store.members {
+		if m.Email == email && m.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// Create stores a new member, returning ErrEmailTaken if the email is in use
+func (r *MemoryMemberRepository) Create(ctx context.Context, member *models.Member) (primitive.ObjectID, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	stored := clone(*member)
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	if r.emailTaken(stored.Email, stored.ID) {
+		return primitive.NilObjectID, ErrEmailTaken
+	}
+	r.Store.members[stored.ID] = stored
+	return stored.ID, nil
+}
+
+// GetAll retrieves all members
+func (r *MemoryMemberRepository) GetAll(ctx context.Context) ([]models.Member, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return sortedByID(r.Store.members), nil
+}
+
+// GetByID retrieves a single member by its ID, returning ErrNotFound if it does not exist
+func (r *MemoryMemberRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Member, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	member, ok := r.Store.members[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	member = clone(member)
+	return &member, nil
+}
+
+// Update replaces a stored member, returning ErrNotFound if it does not exist or
+// ErrEmailTaken if the new email is used by another member
+func (r *MemoryMemberRepository) Update(ctx context.Context, member *models.Member) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if _, ok := r.Store.members[member.ID]; !ok {
+		return ErrNotFound
+	}
+	if r.emailTaken(member.Email, member.ID) {
+		return ErrEmailTaken
+	}
+	r.Store.members[member.ID] = clone(*member)
+	return nil
+}
+
+// Delete removes a member, returning ErrNotFound if it does not exist
+func (r *MemoryMemberRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if _, ok := r.Store.members[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.Store.members, id)
+	return nil
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMemoryBookingRepository(t *testing.T) {
+	ctx := context.Background()
+	date := models.CustomDate(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	newBooking := func(classID, memberID primitive.ObjectID) *models.Booking {
+		return &models.Booking{ClassID: classID, MemberID: memberID, Date: date, Status: models.BookingStatusActive}
+	}
+
+	t.Run("concurrent bookings never exceed capacity", func(t *testing.T) {
+		repo := NewMemoryBookingRepository(NewMemoryStore())
+		classID := primitive.NewObjectID()
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		booked := 0
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := repo.Create(ctx, newBooking(classID, primitive.NewObjectID()), 10); err == nil {
+					mu.Lock()
+					booked++
+					mu.Unlock()
+				} else {
+					assert.ErrorIs(t, err, ErrClassFull)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 10, booked)
+		counts, err := repo.CountBooked(ctx, classID, date, date)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"2025-03-10": 10}, counts)
+	})
+
+	t.Run("a member books a class and date once", func(t *testing.T) {
+		repo := NewMemoryBookingRepository(NewMemoryStore())
+		classID, memberID := primitive.NewObjectID(), primitive.NewObjectID()
+
+		_, err := repo.Create(ctx, newBooking(classID, memberID), 1)
+		assert.NoError(t, err)
+
+		// Already booked wins over full
+		_, err = repo.Create(ctx, newBooking(classID, memberID), 1)
+		assert.ErrorIs(t, err, ErrAlreadyBooked)
+		_, err = repo.Create(ctx, newBooking(classID, memberID), 5)
+		assert.ErrorIs(t, err, ErrAlreadyBooked)
+	})
+
+	t.Run("cancelling releases the spot once", func(t *testing.T) {
+		repo := NewMemoryBookingRepository(NewMemoryStore())
+		classID := primitive.NewObjectID()
+		id, err := repo.Create(ctx, newBooking(classID, primitive.NewObjectID()), 1)
+		assert.NoError(t, err)
+
+		cancelled, err := repo.Cancel(ctx, id, "Feeling unwell")
+		assert.NoError(t, err)
+		assert.Equal(t, models.BookingStatusCancelled, cancelled.Status)
+		assert.Equal(t, "Feeling unwell", cancelled.CancellationReason)
+		assert.NotNil(t, cancelled.CancelledAt)
+
+		_, err = repo.Cancel(ctx, id, "")
+		assert.ErrorIs(t, err, ErrAlreadyCancelled)
+		_, err = repo.Cancel(ctx, primitive.NewObjectID(), "")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		counts, _ := repo.CountBooked(ctx, classID, date, date)
+		assert.Equal(t, 0, counts["2025-03-10"])
+		active, _ := repo.GetActiveByClass(ctx, classID, date)
+		assert.Empty(t, active)
+	})
+
+	t.Run("stored bookings are copies", func(t *testing.T) {
+		repo := NewMemoryBookingRepository(NewMemoryStore())
+		booking := newBooking(primitive.NewObjectID(), primitive.NewObjectID())
+		id, _ := repo.Create(ctx, booking, 1)
+
+		booking.Status = models.BookingStatusCancelled
+		stored, err := repo.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, models.BookingStatusActive, stored.Status)
+	})
+}
+
+func TestMemoryClassRepositoryGetAll(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryClassRepository(NewMemoryStore())
+	for _, name := range []string{"yoga", "Pilates", "Boxing", "Yoga"} {
+		_, err := repo.Create(ctx, &models.Class{Name: name, Capacity: 10})
+		assert.NoError(t, err)
+	}
+
+	names := func(classes []models.Class) []string {
+		var result []string
+		for _, c := range classes {
+			result = append(result, c.Name)
+		}
+		return result
+	}
+
+	t.Run("filters by name", func(t *testing.T) {
+		classes, err := repo.GetAll(ctx, ClassFilter{Name: "YOG"}, Page{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"yoga", "Yoga"}, names(classes))
+	})
+
+	t.Run("pages in sort order", func(t *testing.T) {
+		page := Page{Sort: Sort{Field: "name", Desc: true}, Limit: 2}
+		first, err := repo.GetAll(ctx, ClassFilter{}, page)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"yoga", "Yoga"}, names(first))
+
+		last := first[len(first)-1]
+		_, key, _ := bson.MarshalValue(last.Name)
+		page.After, page.AfterKey = last.ID, bson.RawValue{Type: bson.TypeString, Value: key}
+		second, err := repo.GetAll(ctx, ClassFilter{}, page)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Pilates", "Boxing"}, names(second))
+	})
+}
+
+func TestMemoryMemberRepositoryEmailTaken(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryMemberRepository(NewMemoryStore())
+
+	_, err := repo.Create(ctx, &models.Member{Name: "John Doe", Email: "john@example.com"})
+	assert.NoError(t, err)
+	janeID, err := repo.Create(ctx, &models.Member{Name: "Jane Doe", Email: "jane@example.com"})
+	assert.NoError(t, err)
+
+	_, err = repo.Create(ctx, &models.Member{Name: "Johnny", Email: "john@example.com"})
+	assert.ErrorIs(t, err, ErrEmailTaken)
+	err = repo.Update(ctx, &models.Member{ID: janeID, Name: "Jane Doe", Email: "john@example.com"})
+	assert.ErrorIs(t, err, ErrEmailTaken)
+	err = repo.Update(ctx, &models.Member{ID: primitive.NewObjectID(), Email: "new@example.com"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
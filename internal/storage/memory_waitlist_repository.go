@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryWaitlistRepository is the WaitlistRepositoryInterface of a MemoryStore
+type MemoryWaitlistRepository struct {
+	Store *MemoryStore
+}
+
+// NewMemoryWaitlistRepository initializes a WaitlistRepository backed by a MemoryStore
+func NewMemoryWaitlistRepository(store *MemoryStore) WaitlistRepositoryInterface {
+	return &MemoryWaitlistRepository{Store: store}
+}
+
+// waiting returns the waiting entries of a class occurrence in queue order. The caller holds the store lock.
+func (r *MemoryWaitlistRepository) waiting(classID primitive.ObjectID, date models.CustomDate) []models.WaitlistEntry {
+	var entries []models.WaitlistEntry
+	for _, entry := range sortedByID(r.Store.waitlist) {
+		if entry.ClassID == classID && entry.Date.String() == date.String() && entry.Status == models.WaitlistStatusWaiting {
+			entry.Position = len(entries) + 1
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// isWaiting reports whether a member waits for a class occurrence. The caller holds the store lock.
+func (r *MemoryWaitlistRepository) isWaiting(memberID, classID primitive.ObjectID, date models.CustomDate) bool {
+	for _, entry := range r.waiting(classID, date) {
+		if entry.MemberID == memberID {
+			return true
+		}
+	}
+	return false
+}
+
+// Add queues a waiting entry. Entries are queued in _id order.
+func (r *MemoryWaitlistRepository) Add(ctx context.Context, entry *models.WaitlistEntry) (primitive.ObjectID, int, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if r.isWaiting(entry.MemberID, entry.ClassID, entry.Date) {
+		return primitive.NilObjectID, 0, ErrAlreadyWaitlisted
+	}
+	entry.ID = primitive.NewObjectID()
+	r.Store.waitlist[entry.ID] = clone(*entry)
+
+	position := 0
+	for _, e := range r.waiting(entry.ClassID, entry.Date) {
+		if compareIDs(e.ID, entry.ID) <= 0 {
+			position++
+		}
+	}
+	return entry.ID, position, nil
+}
+
+// List retrieves the waiting entries of a class occurrence ordered by queue position
+func (r *MemoryWaitlistRepository) List(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) ([]models.WaitlistEntry, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.waiting(classID, date), nil
+}
+
+// PopNext claims the first waiting entry of a class occurrence
+func (r *MemoryWaitlistRepository) PopNext(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) (*models.WaitlistEntry, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	entries := r.waiting(classID, date)
+	if len(entries) == 0 {
+		return nil, ErrNotFound
+	}
+	entry := entries[0]
+	entry.Status = models.WaitlistStatusPromoted
+	entry.Position = 0
+	r.Store.waitlist[entry.ID] = clone(entry)
+	return &entry, nil
+}
+
+// Requeue sets a promoted entry back to waiting. Its _id keeps its original queue position.
+func (r *MemoryWaitlistRepository) Requeue(ctx context.Context, id primitive.ObjectID) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if entry, ok := r.Store.waitlist[id]; ok && entry.Status != models.WaitlistStatusWaiting {
+		if r.isWaiting(entry.MemberID, entry.ClassID, entry.Date) {
+			return ErrAlreadyWaitlisted
+		}
+		entry.Status = models.WaitlistStatusWaiting
+		r.Store.waitlist[id] = entry
+	}
+	return nil
+}
+
+// MarkPromoted links a promoted entry to the booking created for it
+func (r *MemoryWaitlistRepository) MarkPromoted(ctx context.Context, id primitive.ObjectID, bookingID primitive.ObjectID) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if entry, ok := r.Store.waitlist[id]; ok {
+		entry.BookingID = &bookingID
+		r.Store.waitlist[id] = clone(entry)
+	}
+	return nil
+}
+
+// RemoveWaiting deletes the waiting entries of a class
+func (r *MemoryWaitlistRepository) RemoveWaiting(ctx context.Context, classID primitive.ObjectID) (int, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	removed := 0
+	for id, entry := range r.Store.waitlist {
+		if entry.ClassID == classID && entry.Status == models.WaitlistStatusWaiting {
+			delete(r.Store.waitlist, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
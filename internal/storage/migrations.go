@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Migrations lists the document migrations in version order. Append new ones with the
+// next version, and never change a migration once released.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "copy the legacy classes, bookings and members databases into the application database",
+		Up: func(ctx context.Context, m *MongoRepository) error {
+			_, err := MigrateLegacyDatabases(ctx, m)
+			return err
+		},
+	},
+	{
+		Version:     2,
+		Description: "store dates as BSON dates",
+		Up: func(ctx context.Context, m *MongoRepository) error {
+			report, err := MigrateLegacyDates(ctx, m)
+			if err != nil {
+				return err
+			}
+			log.Info().Ctx(ctx).Msgf("Date migration converted %d documents", report.Converted)
+			for _, field := range report.Unrecoverable {
+				log.Warn().Ctx(ctx).Msgf("Date lost, fix by hand: %s", field)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     3,
+		Description: "remove duplicate waitlist entries before indexing them as unique",
+		Up: func(ctx context.Context, m *MongoRepository) error {
+			removed, err := RemoveDuplicateWaitlistEntries(ctx, m)
+			if err != nil {
+				return err
+			}
+			log.Info().Ctx(ctx).Msgf("Removed %d duplicate waitlist entries", removed)
+			return nil
+		},
+	},
+	{
+		Version:     4,
+		Description: "mark the bookings stored without a status as active",
+		Up: func(ctx context.Context, m *MongoRepository) error {
+			marked, err := BackfillBookingStatus(ctx, m)
+			if err != nil {
+				return err
+			}
+			log.Info().Ctx(ctx).Msgf("Marked %d bookings active", marked)
+			return nil
+		},
+	},
+	{
+		Version:     5,
+		Description: "count the active bookings of every class occurrence",
+		Up: func(ctx context.Context, m *MongoRepository) error {
+			updated, err := BackfillOccurrences(ctx, m)
+			if err != nil {
+				return err
+			}
+			log.Info().Ctx(ctx).Msgf("Backfilled %d occurrence counters", updated)
+			return nil
+		},
+	},
+}
@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration upgrades the stored documents from the previous schema version to Version
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, m *MongoRepository) error
+	Down        func(ctx context.Context, m *MongoRepository) error // Nil when the migration cannot be reverted
+}
+
+// MigrationStatus is a migration known to the binary or recorded in the database
+type MigrationStatus struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time // Nil while pending
+	Unknown     bool       // Applied by a newer version of the application
+}
+
+// ErrIrreversible is returned when reverting a migration that has no Down
+var ErrIrreversible = errors.New("migration cannot be reverted")
+
+// migrationLockID is the _id of the lock document in the migrations collection
+const migrationLockID = "lock"
+
+// migrationLease is how long the lock is held without being renewed. The holder renews it
+// while migrating, so an instance that crashed releases it after at most this long.
+const migrationLease = time.Minute
+
+// migrationLockPoll is how often an instance waiting for the lock tries again
+var migrationLockPoll = time.Second
+
+// Migrator applies the migrations of a MongoRepository. Only one instance migrates at a
+// time, the others wait for the lock and then find nothing left to do.
+type Migrator struct {
+	Mongo      *MongoRepository
+	Migrations []Migration
+	Versions   *mongo.Collection
+}
+
+// NewMigrator returns a Migrator of the application Migrations
+func NewMigrator(m *MongoRepository) *Migrator {
+	return &Migrator{Mongo: m, Migrations: Migrations, Versions: m.Collection(m.Collections.Migrations)}
+}
+
+// appliedMigration is the record of an applied migration
+type appliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// validate checks the migrations are numbered in increasing order from 1
+func (mg *Migrator) validate() error {
+	for i, migration := range mg.Migrations {
+		if migration.Version <= 0 || (i > 0 && migration.Version <= mg.Migrations[i-1].Version) {
+			return fmt.Errorf("migration %d %q is out of order", migration.Version, migration.Description)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %d has no Up", migration.Version)
+		}
+	}
+	return nil
+}
+
+// applied returns the records of the applied migrations by version
+func (mg *Migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	cursor, err := mg.Versions.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	var records []appliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode applied migrations: %w", err)
+	}
+	applied := make(map[int]appliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// Status lists the known migrations in version order, followed by the ones applied by a newer version
+func (mg *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := mg.validate(); err != nil {
+		return nil, err
+	}
+	applied, err := mg.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	for _, migration := range mg.Migrations {
+		status := MigrationStatus{Version: migration.Version, Description: migration.Description}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	var unknown []MigrationStatus
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		unknown = append(unknown, MigrationStatus{Version: record.Version, Description: record.Description, AppliedAt: &appliedAt, Unknown: true})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+	return append(statuses, unknown...), nil
+}
+
+// Up applies the pending migrations up to and including target, all of them when target is 0.
+// It returns the migrations it applied.
+func (mg *Migrator) Up(ctx context.Context, target int) ([]Migration, error) {
+	if err := mg.validate(); err != nil {
+		return nil, err
+	}
+	unlock, err := mg.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := mg.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, migration := range mg.Migrations {
+		if _, ok := applied[migration.Version]; ok || (target > 0 && migration.Version > target) {
+			continue
+		}
+		log.Info().Ctx(ctx).Msgf("Applying migration %d: %s", migration.Version, migration.Description)
+		if err := migration.Up(ctx, mg.Mongo); err != nil {
+			return done, fmt.Errorf("migration %d failed: %w", migration.Version, err)
+		}
+		record := appliedMigration{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now().UTC()}
+		if _, err := mg.Versions.InsertOne(ctx, record); err != nil {
+			return done, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns them
+func (mg *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if err := mg.validate(); err != nil {
+		return nil, err
+	}
+	unlock, err := mg.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := mg.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+	if steps < len(versions) {
+		versions = versions[:steps]
+	}
+
+	known := map[int]Migration{}
+	for _, migration := range mg.Migrations {
+		known[migration.Version] = migration
+	}
+	var done []Migration
+	for _, version := range versions {
+		migration, ok := known[version]
+		if !ok {
+			return done, fmt.Errorf("migration %d was applied by a newer version, revert it with that version", version)
+		}
+		if migration.Down == nil {
+			return done, fmt.Errorf("migration %d %q: %w", version, migration.Description, ErrIrreversible)
+		}
+		log.Info().Ctx(ctx).Msgf("Reverting migration %d: %s", migration.Version, migration.Description)
+		if err := migration.Down(ctx, mg.Mongo); err != nil {
+			return done, fmt.Errorf("reverting migration %d failed: %w", version, err)
+		}
+		if _, err := mg.Versions.DeleteOne(ctx, bson.M{"_id": version}); err != nil {
+			return done, fmt.Errorf("failed to remove the record of migration %d: %w", version, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// lock waits until no other instance is migrating and takes the migration lock. The
+// returned function releases it.
+func (mg *Migrator) lock(ctx context.Context) (func(), error) {
+	owner := primitive.NewObjectID().Hex()
+	for waiting := false; ; waiting = true {
+		now := time.Now().UTC()
+		// Takes a missing or expired lock. A lock held by another instance does not match,
+		// so the upsert collides with its _id.
+		_, err := mg.Versions.UpdateOne(ctx,
+			bson.M{"_id": migrationLockID, "expires_at": bson.M{"$lt": now}},
+			bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(migrationLease)}},
+			options.Update().SetUpsert(true))
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+		if !waiting {
+			log.Info().Ctx(ctx).Msg("Waiting for another instance to finish migrating")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to take the migration lock: %w", ctx.Err())
+		case <-time.After(migrationLockPoll):
+		}
+	}
+
+	// Renew the lease until released
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(migrationLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_, err := mg.Versions.UpdateOne(context.Background(),
+					bson.M{"_id": migrationLockID, "owner": owner},
+					bson.M{"$set": bson.M{"expires_at": time.Now().UTC().Add(migrationLease)}})
+				if err != nil {
+					log.Error().Ctx(ctx).Err(err).Msg("Error renewing the migration lock")
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-stopped
+		_, err := mg.Versions.DeleteOne(context.Background(), bson.M{"_id": migrationLockID, "owner": owner})
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("Error releasing the migration lock")
+		}
+	}, nil
+}
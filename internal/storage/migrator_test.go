@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMigratorValidate(t *testing.T) {
+	up := func(context.Context, *MongoRepository) error { return nil }
+	tests := []struct {
+		name       string
+		migrations []Migration
+		wantErr    string
+	}{
+		{"application migrations", Migrations, ""},
+		{"none", nil, ""},
+		{"in order with gaps", []Migration{{Version: 1, Up: up}, {Version: 5, Up: up}}, ""},
+		{"out of order", []Migration{{Version: 2, Up: up}, {Version: 1, Description: "first", Up: up}}, `migration 1 "first" is out of order`},
+		{"duplicate", []Migration{{Version: 1, Up: up}, {Version: 1, Up: up}}, `migration 1 "" is out of order`},
+		{"version zero", []Migration{{Version: 0, Up: up}}, `migration 0 "" is out of order`},
+		{"no up", []Migration{{Version: 1}}, "migration 1 has no Up"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Migrator{Migrations: tt.migrations}).validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTestMigrator returns a Migrator on a throwaway database of the MongoDB server at
+// MONGO_URI, skipping the test when it is unset
+func newTestMigrator(t *testing.T, migrations []Migration) *Migrator {
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	db := client.Database("migratortest_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		_ = db.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+
+	migrator := NewMigrator(&MongoRepository{Client: client, Database: db, Collections: DefaultCollections})
+	migrator.Migrations = migrations
+	return migrator
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	var runs [4]int32
+	step := func(version int, reversible bool) Migration {
+		migration := Migration{
+			Version:     version,
+			Description: "step",
+			Up: func(ctx context.Context, m *MongoRepository) error {
+				atomic.AddInt32(&runs[version], 1)
+				_, err := m.Collection("steps").InsertOne(ctx, bson.M{"_id": version})
+				return err
+			},
+		}
+		if reversible {
+			migration.Down = func(ctx context.Context, m *MongoRepository) error {
+				_, err := m.Collection("steps").DeleteOne(ctx, bson.M{"_id": version})
+				return err
+			}
+		}
+		return migration
+	}
+	migrator := newTestMigrator(t, []Migration{step(1, false), step(2, true), step(3, true)})
+	versions := func(migrations []Migration) []int {
+		result := []int{}
+		for _, m := range migrations {
+			result = append(result, m.Version)
+		}
+		return result
+	}
+	pending := func() []int {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		result := []int{}
+		for _, s := range statuses {
+			if s.AppliedAt == nil {
+				result = append(result, s.Version)
+			}
+		}
+		return result
+	}
+
+	t.Run("up to a version", func(t *testing.T) {
+		applied, err := migrator.Up(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, versions(applied))
+		assert.Equal(t, []int{3}, pending())
+	})
+
+	t.Run("concurrent instances apply each migration once", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := migrator.Up(ctx, 0)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, [4]int32{0, 1, 1, 1}, runs)
+		assert.Empty(t, pending())
+	})
+
+	t.Run("down reverts the newest first", func(t *testing.T) {
+		reverted, err := migrator.Down(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{3, 2}, versions(reverted))
+		assert.Equal(t, []int{2, 3}, pending())
+		count, err := migrator.Mongo.Collection("steps").CountDocuments(ctx, bson.M{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("irreversible migrations stay applied", func(t *testing.T) {
+		_, err := migrator.Down(ctx, 1)
+		assert.ErrorIs(t, err, ErrIrreversible)
+		assert.Equal(t, []int{2, 3}, pending())
+	})
+
+	t.Run("migrations of a newer version are reported", func(t *testing.T) {
+		_, err := migrator.Up(ctx, 0)
+		require.NoError(t, err)
+		_, err = migrator.Versions.InsertOne(ctx, appliedMigration{Version: 9, Description: "newer", AppliedAt: time.Now()})
+		require.NoError(t, err)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, statuses, 4)
+		assert.True(t, statuses[3].Unknown)
+		assert.Equal(t, 9, statuses[3].Version)
+
+		_, err = migrator.Down(ctx, 1)
+		assert.ErrorContains(t, err, "applied by a newer version")
+	})
+
+	t.Run("the lock is released", func(t *testing.T) {
+		count, err := migrator.Versions.CountDocuments(ctx, bson.M{"_id": migrationLockID})
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
@@ -2,39 +2,153 @@ package storage
 
 import (
 	"context"
-	"log"
-	"os"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
 
-// MongoRepository handles MongoDB operations
+// DefaultDatabase is the database used when neither the configuration nor the URI name one
+const DefaultDatabase = "glofox"
+
+// Collections names the collections of the application database
+type Collections struct {
+	Classes     string `yaml:"classes"`
+	Bookings    string `yaml:"bookings"`
+	Occurrences string `yaml:"occurrences"` // Booked-spot counters of class occurrences
+	Waitlist    string `yaml:"waitlist"`
+	Members     string `yaml:"members"`
+	Migrations  string `yaml:"migrations"` // Applied schema migrations and the migration lock
+}
+
+// DefaultCollections are the collection names used unless the configuration says otherwise
+var DefaultCollections = Collections{
+	Classes:     "classes",
+	Bookings:    "bookings",
+	Occurrences: "class_occurrences",
+	Waitlist:    "waitlist",
+	Members:     "members",
+	Migrations:  "schema_migrations",
+}
+
+// MongoRepository handles MongoDB operations. Every collection lives in one database,
+// so an operation can span collections in a transaction.
 type MongoRepository struct {
-	Client *mongo.Client
+	Client      *mongo.Client
+	Database    *mongo.Database
+	Collections Collections
 }
 
-// NewMongoRepository initializes MongoDB connection
-func NewMongoRepository() (*MongoRepository, error) {
-	mongoURI := os.Getenv("MONGO_URI")
-	if mongoURI == "" {
-		log.Fatal("MONGO_URI is not set")
+// Validate checks every collection is named and no two share a name
+func (c Collections) Validate() error {
+	names := []struct {
+		field string
+		name  string
+	}{
+		{"classes", c.Classes},
+		{"bookings", c.Bookings},
+		{"occurrences", c.Occurrences},
+		{"waitlist", c.Waitlist},
+		{"members", c.Members},
+		{"migrations", c.Migrations},
+	}
+	used := map[string]string{}
+	for _, n := range names {
+		if n.name == "" {
+			return fmt.Errorf("the %s collection has no name", n.field)
+		}
+		if other, ok := used[n.name]; ok {
+			return fmt.Errorf("the %s and %s collections are both named %q", other, n.field, n.name)
+		}
+		used[n.name] = n.field
+	}
+	return nil
+}
+
+// MongoConfig says how to reach the application database
+type MongoConfig struct {
+	URI         string      `yaml:"uri"`
+	Database    string      `yaml:"database"` // Defaults to the database named in URI, else DefaultDatabase
+	Collections Collections `yaml:"collections"`
+}
+
+// NewMongoRepository initializes MongoDB connection. The monitors are told about every command.
+func NewMongoRepository(cfg MongoConfig, monitors ...*event.CommandMonitor) (*MongoRepository, error) {
+	if cfg.URI == "" {
+		return nil, errors.New("no MongoDB URI configured")
+	}
+	database, err := databaseName(cfg.URI, cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Collections.Validate(); err != nil {
+		return nil, err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	opts := options.Client().ApplyURI(cfg.URI)
+	if len(monitors) > 0 {
+		opts.SetMonitor(combineMonitors(monitors))
+	}
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("Connected to MongoDB")
-	return &MongoRepository{Client: client}, nil
+	log.Info().Ctx(ctx).Msgf("Connected to MongoDB, using database %s", database)
+	return &MongoRepository{Client: client, Database: client.Database(database), Collections: cfg.Collections}, nil
+}
+
+// combineMonitors returns a command monitor passing every event to each of monitors
+func combineMonitors(monitors []*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
+
+// databaseName returns the configured database, else the one named in the URI, else DefaultDatabase
+func databaseName(uri, configured string) (string, error) {
+	if configured != "" {
+		return configured, nil
+	}
+	cs, err := connstring.ParseAndValidate(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid MongoDB URI: %w", err)
+	}
+	if cs.Database != "" {
+		return cs.Database, nil
+	}
+	return DefaultDatabase, nil
 }
 
-// GetCollection returns a MongoDB collection
-func (m *MongoRepository) GetCollection(collectionName string) *mongo.Collection {
-	return m.Client.Database("goflox").Collection(collectionName)
+// Collection returns a collection of the application database
+func (m *MongoRepository) Collection(name string) *mongo.Collection {
+	return m.Database.Collection(name)
 }
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseName(t *testing.T) {
+	tests := []struct {
+		name       string
+		uri        string
+		configured string
+		want       string
+		err        bool
+	}{
+		{"configured wins", "mongodb://localhost:27017/mydatabase", "glofox_prod", "glofox_prod", false},
+		{"database of the URI", "mongodb://localhost:27017/mydatabase?retryWrites=true", "", "mydatabase", false},
+		{"default", "mongodb://localhost:27017", "", DefaultDatabase, false},
+		{"invalid URI", "localhost:27017", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := databaseName(tt.uri, tt.configured)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCollectionsValidate(t *testing.T) {
+	assert.NoError(t, DefaultCollections.Validate())
+
+	collections := DefaultCollections
+	collections.Waitlist = "bookings"
+	assert.EqualError(t, collections.Validate(), `the bookings and waitlist collections are both named "bookings"`)
+
+	collections = DefaultCollections
+	collections.Members = ""
+	assert.EqualError(t, collections.Validate(), "the members collection has no name")
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Sort orders a page by a document field, ties broken by _id. The zero Sort orders by _id alone.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Page selects a slice of a collection in Sort order
+type Page struct {
+	After    primitive.ObjectID // Exclusive, zero starts from the first document
+	AfterKey bson.RawValue      // Sort field value of the After document, unused when sorted by _id
+	Sort     Sort
+	Limit    int // Zero means no limit
+}
+
+// apply restricts a filter document to the page, returning it with the matching find options.
+// The filter must not use $or.
+func (p Page) apply(query bson.M) (bson.M, *options.FindOptions) {
+	opts := options.Find()
+	if p.Limit > 0 {
+		opts.SetLimit(int64(p.Limit))
+	}
+
+	if p.Sort.Field == "" {
+		if !p.After.IsZero() {
+			query["_id"] = bson.M{"$gt": p.After}
+		}
+		return query, opts.SetSort(bson.D{{Key: "_id", Value: 1}})
+	}
+
+	direction, op := 1, "$gt"
+	if p.Sort.Desc {
+		direction, op = -1, "$lt"
+	}
+	if !p.After.IsZero() {
+		query["$or"] = bson.A{
+			bson.M{p.Sort.Field: bson.M{op: p.AfterKey}},
+			bson.M{p.Sort.Field: p.AfterKey, "_id": bson.M{"$gt": p.After}},
+		}
+	}
+	// Compare strings case-insensitively so that "yoga" sorts next to "Yoga"
+	opts.SetCollation(&options.Collation{Locale: "en", Strength: 2})
+	return query, opts.SetSort(bson.D{{Key: p.Sort.Field, Value: direction}, {Key: "_id", Value: 1}})
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPageApply(t *testing.T) {
+	after := primitive.NewObjectID()
+	_, key, _ := bson.MarshalValue("Yoga")
+	afterKey := bson.RawValue{Type: bson.TypeString, Value: key}
+
+	t.Run("first page in ID order", func(t *testing.T) {
+		query, opts := Page{Limit: 10}.apply(bson.M{"status": "active"})
+
+		assert.Equal(t, bson.M{"status": "active"}, query)
+		assert.Equal(t, bson.D{{Key: "_id", Value: 1}}, opts.Sort)
+		assert.Equal(t, int64(10), *opts.Limit)
+		assert.Nil(t, opts.Collation)
+	})
+
+	t.Run("next page in ID order", func(t *testing.T) {
+		query, opts := Page{After: after}.apply(bson.M{})
+
+		assert.Equal(t, bson.M{"_id": bson.M{"$gt": after}}, query)
+		assert.Nil(t, opts.Limit)
+	})
+
+	t.Run("next page sorted by a field descending", func(t *testing.T) {
+		page := Page{After: after, AfterKey: afterKey, Sort: Sort{Field: "name", Desc: true}, Limit: 10}
+		query, opts := page.apply(bson.M{})
+
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"name": bson.M{"$lt": afterKey}},
+			bson.M{"name": afterKey, "_id": bson.M{"$gt": after}},
+		}}, query)
+		assert.Equal(t, bson.D{{Key: "name", Value: -1}, {Key: "_id", Value: 1}}, opts.Sort)
+		assert.NotNil(t, opts.Collation)
+	})
+}
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const bookingColumns = "id, class_id, class_name, member_id, member_name, date, starts_at, status, cancelled_at, cancellation_reason"
+
+// BookingRepository is the BookingRepositoryInterface of a PostgreSQL database
+type BookingRepository struct {
+	Pool *pgxpool.Pool
+}
+
+// NewBookingRepository initializes a BookingRepository on the bookings table
+func NewBookingRepository(pool *pgxpool.Pool) storage.BookingRepositoryInterface {
+	return &BookingRepository{Pool: pool}
+}
+
+// scanBooking reads a row of bookingColumns
+func scanBooking(row pgx.Row) (models.Booking, error) {
+	var (
+		booking             models.Booking
+		id                  string
+		classID, memberID   *string
+		day                 time.Time
+		startsAt, cancelled *time.Time
+	)
+	err := row.Scan(&id, &classID, &booking.ClassName, &memberID, &booking.MemberName, &day,
+		&startsAt, &booking.Status, &cancelled, &booking.CancellationReason)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if booking.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return models.Booking{}, err
+	}
+	if booking.ClassID, err = parseID(classID); err != nil {
+		return models.Booking{}, err
+	}
+	if booking.MemberID, err = parseID(memberID); err != nil {
+		return models.Booking{}, err
+	}
+	booking.Date = models.CustomDate(day).UTC()
+	booking.StartsAt, booking.CancelledAt = utc(startsAt), utc(cancelled)
+	return booking, nil
+}
+
+// Create checks the capacity of the class occurrence and inserts the booking in one
+// serializable transaction, so concurrent bookings cannot overfill the occurrence
+func (r *BookingRepository) Create(ctx context.Context, booking *models.Booking, capacity int) (primitive.ObjectID, error) {
+	id := newID(booking.ID)
+	err := inSerializableTx(ctx, r.Pool, func(tx pgx.Tx) error {
+		var booked, mine int
+		err := tx.QueryRow(ctx, `SELECT count(*), count(*) FILTER (WHERE member_id = $3)
+			FROM bookings WHERE class_id = $1 AND date = $2 AND status = 'active'`,
+			booking.ClassID.Hex(), date(booking.Date), nullableID(booking.MemberID)).Scan(&booked, &mine)
+		if err != nil {
+			return err
+		}
+		// A full class is the less useful answer when the member is already booked on it
+		if mine > 0 && (booked >= capacity || booking.Status == models.BookingStatusActive) {
+			return storage.ErrAlreadyBooked
+		}
+		if booked >= capacity {
+			return storage.ErrClassFull
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO bookings ("+bookingColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+			id.Hex(), booking.ClassID.Hex(), booking.ClassName, nullableID(booking.MemberID), booking.MemberName,
+			date(booking.Date), booking.StartsAt, booking.Status, booking.CancelledAt, booking.CancellationReason)
+		return err
+	})
+	switch {
+	case errors.Is(err, storage.ErrClassFull), errors.Is(err, storage.ErrAlreadyBooked):
+		return primitive.NilObjectID, err
+	case hasCode(err, codeUniqueViolation):
+		return primitive.NilObjectID, storage.ErrAlreadyBooked
+	case hasCode(err, codeForeignKeyViolation):
+		return primitive.NilObjectID, storage.ErrNotFound
+	case err != nil:
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting booking")
+		return primitive.NilObjectID, fmt.Errorf("failed to insert booking: %w", err)
+	}
+	log.Info().Ctx(ctx).Msgf("Inserted booking with ID: %v", id)
+	return id, nil
+}
+
+// queryBookings runs a query returning bookingColumns
+func (r *BookingRepository) queryBookings(ctx context.Context, sql string, args ...any) ([]models.Booking, error) {
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding bookings")
+		return nil, fmt.Errorf("failed to find bookings: %w", err)
+	}
+	bookings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Booking, error) { return scanBooking(row) })
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error decoding booking")
+		return nil, fmt.Errorf("failed to decode booking: %w", err)
+	}
+	return bookings, nil
+}
+
+// GetAll retrieves a page of the bookings matching the filter
+func (r *BookingRepository) GetAll(ctx context.Context, filter storage.BookingFilter, p storage.Page) ([]models.Booking, error) {
+	w := bookingWhere(filter)
+	order, err := page(w, p, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryBookings(ctx, "SELECT "+bookingColumns+" FROM bookings"+w.String()+order, w.args...)
+}
+
+// GetByID retrieves a single booking by its ID, returning ErrNotFound if it does not exist
+func (r *BookingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Booking, error) {
+	booking, err := scanBooking(r.Pool.QueryRow(ctx, "SELECT "+bookingColumns+" FROM bookings WHERE id = $1", id.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding booking %s", id.Hex())
+		return nil, fmt.Errorf("failed to find booking: %w", err)
+	}
+	return &booking, nil
+}
+
+// CountBooked counts the active bookings of a class per date between two dates, inclusive
+func (r *BookingRepository) CountBooked(ctx context.Context, classID primitive.ObjectID, from, to models.CustomDate) (map[string]int, error) {
+	rows, err := r.Pool.Query(ctx, `SELECT date, count(*) FROM bookings
+		WHERE class_id = $1 AND date BETWEEN $2 AND $3 AND status = 'active' GROUP BY date`,
+		classID.Hex(), date(from), date(to))
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error counting bookings of class %s", classID.Hex())
+		return nil, fmt.Errorf("failed to count bookings: %w", err)
+	}
+	defer rows.Close()
+
+	booked := map[string]int{}
+	for rows.Next() {
+		var (
+			day   time.Time
+			count int
+		)
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("failed to count bookings: %w", err)
+		}
+		booked[models.CustomDate(day).String()] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count bookings: %w", err)
+	}
+	return booked, nil
+}
+
+// GetActiveByClass retrieves the bookings of a class dated from on that are not cancelled, in creation order
+func (r *BookingRepository) GetActiveByClass(ctx context.Context, classID primitive.ObjectID, from models.CustomDate) ([]models.Booking, error) {
+	return r.queryBookings(ctx, "SELECT "+bookingColumns+" FROM bookings WHERE class_id = $1 AND date >= $2 AND status <> 'cancelled' ORDER BY id",
+		classID.Hex(), date(from))
+}
+
+// Cancel marks a booking as cancelled, which releases its spot since capacity counts active bookings
+func (r *BookingRepository) Cancel(ctx context.Context, id primitive.ObjectID, reason string) (*models.Booking, error) {
+	booking, err := scanBooking(r.Pool.QueryRow(ctx, `UPDATE bookings
+		SET status = 'cancelled', cancelled_at = $2, cancellation_reason = $3
+		WHERE id = $1 AND status <> 'cancelled' RETURNING `+bookingColumns,
+		id.Hex(), time.Now().UTC(), reason))
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, storage.ErrAlreadyCancelled
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error cancelling booking %s", id.Hex())
+		return nil, fmt.Errorf("failed to cancel booking: %w", err)
+	}
+	log.Info().Ctx(ctx).Msgf("Cancelled booking with ID: %v", id)
+	return &booking, nil
+}
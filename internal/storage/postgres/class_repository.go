@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const classColumns = "id, name, start_date, end_date, capacity, start_time, duration_minutes, timezone, recurrence"
+
+// ClassRepository is the ClassRepositoryInterface of a PostgreSQL database
+type ClassRepository struct {
+	Pool *pgxpool.Pool
+}
+
+// NewClassRepository initializes a ClassRepository on the classes table
+func NewClassRepository(pool *pgxpool.Pool) storage.ClassRepositoryInterface {
+	return &ClassRepository{Pool: pool}
+}
+
+// scanClass reads a row of classColumns
+func scanClass(row pgx.Row) (models.Class, error) {
+	var (
+		class      models.Class
+		id         string
+		start, end time.Time
+		recurrence []byte
+	)
+	err := row.Scan(&id, &class.Name, &start, &end, &class.Capacity, &class.StartTime,
+		&class.DurationMinutes, &class.Timezone, &recurrence)
+	if err != nil {
+		return models.Class{}, err
+	}
+	if class.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return models.Class{}, err
+	}
+	class.StartDate, class.EndDate = models.CustomDate(start).UTC(), models.CustomDate(end).UTC()
+	if recurrence != nil {
+		class.Recurrence = &models.RecurrenceRule{}
+		if err := json.Unmarshal(recurrence, class.Recurrence); err != nil {
+			return models.Class{}, fmt.Errorf("invalid recurrence of class %s: %w", id, err)
+		}
+	}
+	return class, nil
+}
+
+// recurrenceValue encodes the recurrence rule of a class, NULL when it runs every day
+func recurrenceValue(class *models.Class) (any, error) {
+	if class.Recurrence == nil {
+		return nil, nil
+	}
+	return json.Marshal(class.Recurrence)
+}
+
+// Create inserts a new class
+func (r *ClassRepository) Create(ctx context.Context, class *models.Class) (primitive.ObjectID, error) {
+	recurrence, err := recurrenceValue(class)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	id := newID(class.ID)
+	_, err = r.Pool.Exec(ctx, "INSERT INTO classes ("+classColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		id.Hex(), class.Name, date(class.StartDate), date(class.EndDate), class.Capacity,
+		class.StartTime, class.DurationMinutes, class.Timezone, recurrence)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting class")
+		return primitive.NilObjectID, fmt.Errorf("failed to insert class: %w", err)
+	}
+	log.Info().Ctx(ctx).Msgf("Inserted class with ID: %v", id)
+	return id, nil
+}
+
+// GetAll retrieves a page of the classes matching the filter
+func (r *ClassRepository) GetAll(ctx context.Context, filter storage.ClassFilter, p storage.Page) ([]models.Class, error) {
+	w := classWhere(filter)
+	order, err := page(w, p, classSortColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.Pool.Query(ctx, "SELECT "+classColumns+" FROM classes"+w.String()+order, w.args...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding classes")
+		return nil, fmt.Errorf("failed to find classes: %w", err)
+	}
+	classes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Class, error) { return scanClass(row) })
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error decoding class")
+		return nil, fmt.Errorf("failed to decode class: %w", err)
+	}
+	return classes, nil
+}
+
+// GetByID retrieves a single class by its ID, returning ErrNotFound if it does not exist
+func (r *ClassRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Class, error) {
+	class, err := scanClass(r.Pool.QueryRow(ctx, "SELECT "+classColumns+" FROM classes WHERE id = $1", id.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding class %s", id.Hex())
+		return nil, fmt.Errorf("failed to find class: %w", err)
+	}
+	return &class, nil
+}
+
+// Update replaces a class, returning ErrNotFound if it does not exist
+func (r *ClassRepository) Update(ctx context.Context, class *models.Class) error {
+	recurrence, err := recurrenceValue(class)
+	if err != nil {
+		return err
+	}
+	tag, err := r.Pool.Exec(ctx, `UPDATE classes SET name = $2, start_date = $3, end_date = $4, capacity = $5,
+		start_time = $6, duration_minutes = $7, timezone = $8, recurrence = $9 WHERE id = $1`,
+		class.ID.Hex(), class.Name, date(class.StartDate), date(class.EndDate), class.Capacity,
+		class.StartTime, class.DurationMinutes, class.Timezone, recurrence)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error updating class %s", class.ID.Hex())
+		return fmt.Errorf("failed to update class: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Updated class with ID: %v", class.ID)
+	return nil
+}
+
+// Delete removes a class, returning ErrNotFound if it does not exist. Its bookings are kept
+// without the class reference and its waitlist is removed with it.
+func (r *ClassRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	tag, err := r.Pool.Exec(ctx, "DELETE FROM classes WHERE id = $1", id.Hex())
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error deleting class %s", id.Hex())
+		return fmt.Errorf("failed to delete class: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Deleted class with ID: %v", id)
+	return nil
+}
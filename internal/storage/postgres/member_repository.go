@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const memberColumns = "id, name, email, status, created_at"
+
+// MemberRepository is the MemberRepositoryInterface of a PostgreSQL database
+type MemberRepository struct {
+	Pool *pgxpool.Pool
+}
+
+// NewMemberRepository initializes a MemberRepository on the members table.
+// Email uniqueness relies on the table's unique constraint.
+func NewMemberRepository(pool *pgxpool.Pool) storage.MemberRepositoryInterface {
+	return &MemberRepository{Pool: pool}
+}
+
+// scanMember reads a row of memberColumns
+func scanMember(row pgx.Row) (models.Member, error) {
+	var (
+		member models.Member
+		id     string
+	)
+	if err := row.Scan(&id, &member.Name, &member.Email, &member.Status, &member.CreatedAt); err != nil {
+		return models.Member{}, err
+	}
+	var err error
+	if member.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return models.Member{}, err
+	}
+	member.CreatedAt = member.CreatedAt.UTC()
+	return member, nil
+}
+
+// Create inserts a new member, returning ErrEmailTaken if the email is in use
+func (r *MemberRepository) Create(ctx context.Context, member *models.Member) (primitive.ObjectID, error) {
+	id := newID(member.ID)
+	_, err := r.Pool.Exec(ctx, "INSERT INTO members ("+memberColumns+") VALUES ($1, $2, $3, $4, $5)",
+		id.Hex(), member.Name, member.Email, member.Status, member.CreatedAt)
+	if hasCode(err, codeUniqueViolation) {
+		return primitive.NilObjectID, storage.ErrEmailTaken
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting member")
+		return primitive.NilObjectID, fmt.Errorf("failed to insert member: %w", err)
+	}
+	log.Info().Ctx(ctx).Msgf("Inserted member with ID: %v", id)
+	return id, nil
+}
+
+// GetAll retrieves all members
+func (r *MemberRepository) GetAll(ctx context.Context) ([]models.Member, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+memberColumns+" FROM members ORDER BY id")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding members")
+		return nil, fmt.Errorf("failed to find members: %w", err)
+	}
+	members, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Member, error) { return scanMember(row) })
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error decoding member")
+		return nil, fmt.Errorf("failed to decode member: %w", err)
+	}
+	return members, nil
+}
+
+// GetByID retrieves a single member by its ID, returning ErrNotFound if it does not exist
+func (r *MemberRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Member, error) {
+	member, err := scanMember(r.Pool.QueryRow(ctx, "SELECT "+memberColumns+" FROM members WHERE id = $1", id.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error finding member %s", id.Hex())
+		return nil, fmt.Errorf("failed to find member: %w", err)
+	}
+	return &member, nil
+}
+
+// Update replaces a member, returning ErrNotFound if it does not exist or
+// ErrEmailTaken if the new email is used by another member
+func (r *MemberRepository) Update(ctx context.Context, member *models.Member) error {
+	tag, err := r.Pool.Exec(ctx, "UPDATE members SET name = $2, email = $3, status = $4, created_at = $5 WHERE id = $1",
+		member.ID.Hex(), member.Name, member.Email, member.Status, member.CreatedAt)
+	if hasCode(err, codeUniqueViolation) {
+		return storage.ErrEmailTaken
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error updating member %s", member.ID.Hex())
+		return fmt.Errorf("failed to update member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Updated member with ID: %v", member.ID)
+	return nil
+}
+
+// Delete removes a member, returning ErrNotFound if it does not exist
+func (r *MemberRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	tag, err := r.Pool.Exec(ctx, "DELETE FROM members WHERE id = $1", id.Hex())
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error deleting member %s", id.Hex())
+		return fmt.Errorf("failed to delete member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	log.Info().Ctx(ctx).Msgf("Deleted member with ID: %v", id)
+	return nil
+}
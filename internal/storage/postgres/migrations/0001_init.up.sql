@@ -0,0 +1,53 @@
+-- IDs are the hex form of the ObjectIDs used by the API, so they sort in creation order
+CREATE TABLE classes (
+    id               CHAR(24) PRIMARY KEY,
+    name             TEXT     NOT NULL,
+    start_date       DATE     NOT NULL,
+    end_date         DATE     NOT NULL,
+    capacity         INTEGER  NOT NULL CHECK (capacity >= 0),
+    start_time       TEXT     NOT NULL DEFAULT '',
+    duration_minutes INTEGER  NOT NULL DEFAULT 0,
+    timezone         TEXT     NOT NULL DEFAULT '',
+    recurrence       JSONB
+);
+
+CREATE TABLE members (
+    id         CHAR(24)    PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    email      TEXT        NOT NULL UNIQUE,
+    status     TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- Bookings outlive their class, deleting a class keeps its booking history without the reference
+CREATE TABLE bookings (
+    id                  CHAR(24)    PRIMARY KEY,
+    class_id            CHAR(24)    REFERENCES classes (id) ON DELETE SET NULL,
+    class_name          TEXT        NOT NULL,
+    member_id           CHAR(24),
+    member_name         TEXT        NOT NULL,
+    date                DATE        NOT NULL,
+    starts_at           TIMESTAMPTZ,
+    status              TEXT        NOT NULL,
+    cancelled_at        TIMESTAMPTZ,
+    cancellation_reason TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX bookings_class_date ON bookings (class_id, date);
+
+-- At most one active booking per member, class and date
+CREATE UNIQUE INDEX bookings_active_unique ON bookings (member_id, class_id, date)
+    WHERE status = 'active' AND member_id IS NOT NULL;
+
+CREATE TABLE waitlist (
+    id          CHAR(24)    PRIMARY KEY,
+    class_id    CHAR(24)    NOT NULL REFERENCES classes (id) ON DELETE CASCADE,
+    date        DATE        NOT NULL,
+    member_id   CHAR(24),
+    member_name TEXT        NOT NULL,
+    status      TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    booking_id  CHAR(24)
+);
+
+CREATE INDEX waitlist_queue ON waitlist (class_id, date, status, id);
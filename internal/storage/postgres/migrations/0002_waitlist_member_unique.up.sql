@@ -0,0 +1,9 @@
+-- A member waits at most once for a class occurrence. Copies queued before this rule are
+-- dropped, the earliest entry keeps its place.
+DELETE FROM waitlist w
+USING waitlist earlier
+WHERE w.status = 'waiting' AND earlier.status = 'waiting'
+    AND w.member_id = earlier.member_id AND w.class_id = earlier.class_id AND w.date = earlier.date
+    AND earlier.id < w.id;
+
+CREATE UNIQUE INDEX waitlist_member_unique ON waitlist (member_id, class_id, date) WHERE status = 'waiting';
@@ -0,0 +1,125 @@
+// Package postgres implements the storage repositories on PostgreSQL.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostgreSQL error codes the repositories translate
+const (
+	codeUniqueViolation      = "23505"
+	codeForeignKeyViolation  = "23503"
+	codeSerializationFailure = "40001"
+)
+
+// maxTxAttempts bounds the retries of a serializable transaction that lost a conflict.
+// Every round of conflicting transactions commits at least one, so busy occurrences settle quickly.
+const maxTxAttempts = 20
+
+// NewBackend connects to the database at url, applies the pending migrations and returns its repositories
+func NewBackend(ctx context.Context, url string) (*storage.Backend, error) {
+	if url == "" {
+		return nil, errors.New("POSTGRES_URL is not set")
+	}
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	if err := Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return NewPoolBackend(pool), nil
+}
+
+// NewPoolBackend returns the repositories of a connection pool whose schema is migrated
+func NewPoolBackend(pool *pgxpool.Pool) *storage.Backend {
+	return &storage.Backend{
+		Classes:  NewClassRepository(pool),
+		Bookings: NewBookingRepository(pool),
+		Waitlist: NewWaitlistRepository(pool),
+		Members:  NewMemberRepository(pool),
+		Pinger:   pool.Ping,
+		Closer: func(context.Context) error {
+			pool.Close()
+			return nil
+		},
+	}
+}
+
+// hasCode reports whether err is a PostgreSQL error with the given SQLSTATE code
+func hasCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+// inSerializableTx runs fn in a serializable transaction, retrying when it loses a conflict
+// with a concurrent transaction
+func inSerializableTx(ctx context.Context, pool *pgxpool.Pool, fn func(pgx.Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		err := pgx.BeginTxFunc(ctx, pool, pgx.TxOptions{IsoLevel: pgx.Serializable}, fn)
+		if !hasCode(err, codeSerializationFailure) || attempt == maxTxAttempts {
+			return err
+		}
+		// Back off a little so the transactions that conflicted do not collide again
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(rand.Intn(attempt*5)+1) * time.Millisecond):
+		}
+	}
+}
+
+// newID returns id, or a new ObjectID when id is zero, like MongoDB does on insert
+func newID(id primitive.ObjectID) primitive.ObjectID {
+	if id.IsZero() {
+		return primitive.NewObjectID()
+	}
+	return id
+}
+
+// nullableID stores a zero ObjectID as NULL
+func nullableID(id primitive.ObjectID) *string {
+	if id.IsZero() {
+		return nil
+	}
+	hex := id.Hex()
+	return &hex
+}
+
+// parseID reads a stored ID, NULL being the zero ObjectID
+func parseID(hex *string) (primitive.ObjectID, error) {
+	if hex == nil {
+		return primitive.NilObjectID, nil
+	}
+	return primitive.ObjectIDFromHex(*hex)
+}
+
+// utc normalises an optional timestamp read from the database to UTC, as the MongoDB driver returns them
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// date converts a CustomDate to the value of a DATE column
+func date(d models.CustomDate) time.Time {
+	return d.UTC().ToTime()
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/internal/storage/storagetest"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newTestPool connects to the database at POSTGRES_TEST_URL, skipping the test when it is
+// unset, and migrates a throwaway schema that is dropped when the test ends
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL is not set")
+	}
+	ctx := context.Background()
+
+	admin, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	schema := "test_" + primitive.NewObjectID().Hex()
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if _, err := admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Logf("failed to drop schema %s: %v", schema, err)
+		}
+		admin.Close()
+	})
+
+	config, err := pgxpool.ParseConfig(url)
+	require.NoError(t, err)
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	require.NoError(t, Migrate(ctx, pool))
+	return pool
+}
+
+func TestMigrate(t *testing.T) {
+	pool := newTestPool(t)
+
+	// Every embedded migration is recorded, and running them again is a no-op
+	files, err := fs.Glob(migrations, "migrations/*.up.sql")
+	require.NoError(t, err)
+	assert.NoError(t, Migrate(context.Background(), pool))
+	var versions int
+	assert.NoError(t, pool.QueryRow(context.Background(), "SELECT count(*) FROM schema_migrations").Scan(&versions))
+	assert.Equal(t, len(files), versions)
+}
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) *storage.Backend {
+		return NewPoolBackend(newTestPool(t))
+	})
+}
+
+func TestBookingClassReference(t *testing.T) {
+	ctx := context.Background()
+	backend := NewPoolBackend(newTestPool(t))
+	date := models.CustomDate(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	newBooking := func(classID primitive.ObjectID) *models.Booking {
+		return &models.Booking{ClassID: classID, MemberID: primitive.NewObjectID(), Date: date, Status: models.BookingStatusActive}
+	}
+
+	t.Run("bookings reference an existing class", func(t *testing.T) {
+		_, err := backend.Bookings.Create(ctx, newBooking(primitive.NewObjectID()), 5)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("deleting a class keeps its bookings", func(t *testing.T) {
+		classID, err := backend.Classes.Create(ctx, &models.Class{Name: "Yoga", StartDate: date, EndDate: date, Capacity: 10})
+		require.NoError(t, err)
+		id, err := backend.Bookings.Create(ctx, newBooking(classID), 10)
+		require.NoError(t, err)
+
+		require.NoError(t, backend.Classes.Delete(ctx, classID))
+		booking, err := backend.Bookings.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.True(t, booking.ClassID.IsZero())
+	})
+}
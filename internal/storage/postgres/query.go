@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// where collects the conditions of a query and their numbered arguments
+type where struct {
+	conds []string
+	args  []any
+}
+
+// arg adds an argument and returns its placeholder
+func (w *where) arg(value any) string {
+	w.args = append(w.args, value)
+	return fmt.Sprintf("$%d", len(w.args))
+}
+
+// add adds a condition, formatted with the placeholders of its arguments
+func (w *where) add(format string, values ...any) {
+	placeholders := make([]any, len(values))
+	for i, value := range values {
+		placeholders[i] = w.arg(value)
+	}
+	w.conds = append(w.conds, fmt.Sprintf(format, placeholders...))
+}
+
+// String returns the WHERE clause, empty when there are no conditions
+func (w *where) String() string {
+	if len(w.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conds, " AND ")
+}
+
+// classWhere translates a ClassFilter to SQL conditions
+func classWhere(f storage.ClassFilter) *where {
+	w := &where{}
+	if f.Name != "" {
+		w.add("strpos(lower(name), lower(%s)) > 0", f.Name)
+	}
+	if f.From != nil {
+		w.add("end_date >= %s", date(*f.From))
+	}
+	if f.To != nil {
+		w.add("start_date <= %s", date(*f.To))
+	}
+	return w
+}
+
+// bookingWhere translates a BookingFilter to SQL conditions
+func bookingWhere(f storage.BookingFilter) *where {
+	w := &where{}
+	if !f.ClassID.IsZero() {
+		w.add("class_id = %s", f.ClassID.Hex())
+	}
+	if !f.MemberID.IsZero() {
+		w.add("member_id = %s", f.MemberID.Hex())
+	}
+	if f.MemberName != "" {
+		w.add("lower(member_name) = lower(%s)", f.MemberName)
+	}
+	if f.Date != nil {
+		w.add("date = %s", date(*f.Date))
+	}
+	if f.From != nil {
+		w.add("date >= %s", date(*f.From))
+	}
+	if f.To != nil {
+		w.add("date <= %s", date(*f.To))
+	}
+	switch f.Status {
+	case "":
+	case models.BookingStatusActive:
+		w.add("status <> %s", models.BookingStatusCancelled)
+	default:
+		w.add("status = %s", f.Status)
+	}
+	return w
+}
+
+// classSortColumns maps the sortable fields of a class to their columns. Text compares
+// case-insensitively, like the collation Page.apply sets on MongoDB.
+var classSortColumns = map[string]string{
+	"name":       "lower(name)",
+	"start_date": "start_date",
+	"capacity":   "capacity",
+}
+
+// page restricts the conditions to the page and returns its ORDER BY and LIMIT clauses.
+// columns holds the fields the table can be sorted by.
+func page(w *where, p storage.Page, columns map[string]string) (string, error) {
+	order := " ORDER BY id"
+	if p.Sort.Field == "" {
+		if !p.After.IsZero() {
+			w.add("id > %s", p.After.Hex())
+		}
+	} else {
+		column, ok := columns[p.Sort.Field]
+		if !ok {
+			return "", fmt.Errorf("cannot sort by %q", p.Sort.Field)
+		}
+		direction, op := "", ">"
+		if p.Sort.Desc {
+			direction, op = " DESC", "<"
+		}
+		if !p.After.IsZero() {
+			key, err := keyValue(p.AfterKey)
+			if err != nil {
+				return "", err
+			}
+			k, id := w.arg(key), w.arg(p.After.Hex())
+			w.conds = append(w.conds, fmt.Sprintf("(%[1]s %[2]s %[3]s OR (%[1]s = %[3]s AND id > %[4]s))", column, op, k, id))
+		}
+		order = fmt.Sprintf(" ORDER BY %s%s, id", column, direction)
+	}
+	if p.Limit > 0 {
+		order += fmt.Sprintf(" LIMIT %d", p.Limit)
+	}
+	return order, nil
+}
+
+// keyValue converts the BSON sort key of a cursor to a query argument
+func keyValue(key bson.RawValue) (any, error) {
+	switch key.Type {
+	case bson.TypeString:
+		return strings.ToLower(key.StringValue()), nil
+	case bson.TypeDateTime:
+		return key.Time().UTC(), nil
+	}
+	if n, ok := key.AsInt64OK(); ok {
+		return n, nil
+	}
+	return nil, fmt.Errorf("unsupported sort key of type %s", key.Type)
+}
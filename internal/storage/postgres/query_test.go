@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBookingWhere(t *testing.T) {
+	day := models.CustomDate(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	classID, memberID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	tests := []struct {
+		name   string
+		filter storage.BookingFilter
+		sql    string
+		args   []any
+	}{
+		{"no filter", storage.BookingFilter{}, "", nil},
+		{
+			name:   "class and member",
+			filter: storage.BookingFilter{ClassID: classID, MemberID: memberID},
+			sql:    " WHERE class_id = $1 AND member_id = $2",
+			args:   []any{classID.Hex(), memberID.Hex()},
+		},
+		{
+			name:   "member name and status",
+			filter: storage.BookingFilter{MemberName: "Jane", Status: models.BookingStatusActive},
+			sql:    " WHERE lower(member_name) = lower($1) AND status <> $2",
+			args:   []any{"Jane", "cancelled"},
+		},
+		{
+			name:   "cancelled status",
+			filter: storage.BookingFilter{Status: models.BookingStatusCancelled},
+			sql:    " WHERE status = $1",
+			args:   []any{"cancelled"},
+		},
+		{
+			name:   "date range",
+			filter: storage.BookingFilter{From: &day, To: &day},
+			sql:    " WHERE date >= $1 AND date <= $2",
+			args:   []any{day.ToTime(), day.ToTime()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := bookingWhere(tt.filter)
+			assert.Equal(t, tt.sql, w.String())
+			assert.Equal(t, tt.args, w.args)
+		})
+	}
+}
+
+func TestClassWhere(t *testing.T) {
+	day := models.CustomDate(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	w := classWhere(storage.ClassFilter{Name: "yo%ga", From: &day, To: &day})
+
+	// The name is matched as a plain substring, LIKE wildcards included
+	assert.Equal(t, " WHERE strpos(lower(name), lower($1)) > 0 AND end_date >= $2 AND start_date <= $3", w.String())
+	assert.Equal(t, []any{"yo%ga", day.ToTime(), day.ToTime()}, w.args)
+}
+
+func TestPage(t *testing.T) {
+	after := primitive.NewObjectID()
+	key := func(value interface{}) bson.RawValue {
+		kind, data, err := bson.MarshalValue(value)
+		assert.NoError(t, err)
+		return bson.RawValue{Type: kind, Value: data}
+	}
+
+	tests := []struct {
+		name  string
+		page  storage.Page
+		order string
+		where string
+		args  []any
+		err   bool
+	}{
+		{name: "first page by id", page: storage.Page{Limit: 10}, order: " ORDER BY id LIMIT 10"},
+		{
+			name:  "next page by id",
+			page:  storage.Page{After: after, Limit: 10},
+			order: " ORDER BY id LIMIT 10",
+			where: " WHERE id > $1",
+			args:  []any{after.Hex()},
+		},
+		{
+			name:  "first page by name",
+			page:  storage.Page{Sort: storage.Sort{Field: "name"}},
+			order: " ORDER BY lower(name), id",
+		},
+		{
+			name:  "next page by name",
+			page:  storage.Page{After: after, AfterKey: key("Yoga"), Sort: storage.Sort{Field: "name"}, Limit: 5},
+			order: " ORDER BY lower(name), id LIMIT 5",
+			where: " WHERE (lower(name) > $1 OR (lower(name) = $1 AND id > $2))",
+			args:  []any{"yoga", after.Hex()},
+		},
+		{
+			name:  "next page by descending capacity",
+			page:  storage.Page{After: after, AfterKey: key(int32(20)), Sort: storage.Sort{Field: "capacity", Desc: true}},
+			order: " ORDER BY capacity DESC, id",
+			where: " WHERE (capacity < $1 OR (capacity = $1 AND id > $2))",
+			args:  []any{int64(20), after.Hex()},
+		},
+		{
+			name:  "next page by start date",
+			page:  storage.Page{After: after, AfterKey: key(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)), Sort: storage.Sort{Field: "start_date"}},
+			order: " ORDER BY start_date, id",
+			where: " WHERE (start_date > $1 OR (start_date = $1 AND id > $2))",
+			args:  []any{time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), after.Hex()},
+		},
+		{name: "unknown sort field", page: storage.Page{Sort: storage.Sort{Field: "room"}}, err: true},
+		{
+			name: "unsupported sort key",
+			page: storage.Page{After: after, AfterKey: key(true), Sort: storage.Sort{Field: "name"}},
+			err:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &where{}
+			order, err := page(w, tt.page, classSortColumns)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.order, order)
+			assert.Equal(t, tt.where, w.String())
+			assert.Equal(t, tt.args, w.args)
+		})
+	}
+}
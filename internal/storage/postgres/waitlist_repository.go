@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const waitlistColumns = "id, class_id, date, member_id, member_name, status, created_at, booking_id"
+
+// WaitlistRepository is the WaitlistRepositoryInterface of a PostgreSQL database
+type WaitlistRepository struct {
+	Pool *pgxpool.Pool
+}
+
+// NewWaitlistRepository initializes a WaitlistRepository on the waitlist table
+func NewWaitlistRepository(pool *pgxpool.Pool) storage.WaitlistRepositoryInterface {
+	return &WaitlistRepository{Pool: pool}
+}
+
+// scanWaitlistEntry reads a row of waitlistColumns
+func scanWaitlistEntry(row pgx.Row) (models.WaitlistEntry, error) {
+	var (
+		entry               models.WaitlistEntry
+		id, classID         string
+		memberID, bookingID *string
+		day                 time.Time
+	)
+	err := row.Scan(&id, &classID, &day, &memberID, &entry.MemberName, &entry.Status, &entry.CreatedAt, &bookingID)
+	if err != nil {
+		return models.WaitlistEntry{}, err
+	}
+	if entry.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return models.WaitlistEntry{}, err
+	}
+	if entry.ClassID, err = primitive.ObjectIDFromHex(classID); err != nil {
+		return models.WaitlistEntry{}, err
+	}
+	if entry.MemberID, err = parseID(memberID); err != nil {
+		return models.WaitlistEntry{}, err
+	}
+	if bookingID != nil {
+		booking, err := primitive.ObjectIDFromHex(*bookingID)
+		if err != nil {
+			return models.WaitlistEntry{}, err
+		}
+		entry.BookingID = &booking
+	}
+	entry.Date = models.CustomDate(day).UTC()
+	entry.CreatedAt = entry.CreatedAt.UTC()
+	return entry, nil
+}
+
+// Add queues a waiting entry. Entries are queued in ID order.
+func (r *WaitlistRepository) Add(ctx context.Context, entry *models.WaitlistEntry) (primitive.ObjectID, int, error) {
+	entry.ID = primitive.NewObjectID()
+	var bookingID *string
+	if entry.BookingID != nil {
+		bookingID = nullableID(*entry.BookingID)
+	}
+	_, err := r.Pool.Exec(ctx, "INSERT INTO waitlist ("+waitlistColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		entry.ID.Hex(), entry.ClassID.Hex(), date(entry.Date), nullableID(entry.MemberID), entry.MemberName,
+		entry.Status, entry.CreatedAt, bookingID)
+	if hasCode(err, codeForeignKeyViolation) {
+		return primitive.NilObjectID, 0, storage.ErrNotFound
+	}
+	if hasCode(err, codeUniqueViolation) {
+		return primitive.NilObjectID, 0, storage.ErrAlreadyWaitlisted
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting waitlist entry")
+		return primitive.NilObjectID, 0, fmt.Errorf("failed to insert waitlist entry: %w", err)
+	}
+
+	var position int
+	err = r.Pool.QueryRow(ctx, `SELECT count(*) FROM waitlist
+		WHERE class_id = $1 AND date = $2 AND status = 'waiting' AND id <= $3`,
+		entry.ClassID.Hex(), date(entry.Date), entry.ID.Hex()).Scan(&position)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error counting waitlist position")
+		return primitive.NilObjectID, 0, fmt.Errorf("failed to count waitlist position: %w", err)
+	}
+	return entry.ID, position, nil
+}
+
+// List retrieves the waiting entries of a class occurrence ordered by queue position
+func (r *WaitlistRepository) List(ctx context.Context, classID primitive.ObjectID, d models.CustomDate) ([]models.WaitlistEntry, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+waitlistColumns+` FROM waitlist
+		WHERE class_id = $1 AND date = $2 AND status = 'waiting' ORDER BY id`, classID.Hex(), date(d))
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding waitlist entries")
+		return nil, fmt.Errorf("failed to find waitlist entries: %w", err)
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WaitlistEntry, error) { return scanWaitlistEntry(row) })
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error decoding waitlist entry")
+		return nil, fmt.Errorf("failed to decode waitlist entry: %w", err)
+	}
+	for i := range entries {
+		entries[i].Position = i + 1
+	}
+	return entries, nil
+}
+
+// PopNext claims the first waiting entry of a class occurrence. Concurrent callers skip
+// the row another one has locked, so each entry is popped once.
+func (r *WaitlistRepository) PopNext(ctx context.Context, classID primitive.ObjectID, d models.CustomDate) (*models.WaitlistEntry, error) {
+	entry, err := scanWaitlistEntry(r.Pool.QueryRow(ctx, `UPDATE waitlist SET status = 'promoted'
+		WHERE id = (
+			SELECT id FROM waitlist WHERE class_id = $1 AND date = $2 AND status = 'waiting'
+			ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED
+		) RETURNING `+waitlistColumns, classID.Hex(), date(d)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error popping waitlist entry")
+		return nil, fmt.Errorf("failed to pop waitlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// Requeue sets a promoted entry back to waiting. Its ID keeps its original queue position.
+func (r *WaitlistRepository) Requeue(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.Pool.Exec(ctx, "UPDATE waitlist SET status = 'waiting' WHERE id = $1", id.Hex())
+	if hasCode(err, codeUniqueViolation) {
+		return storage.ErrAlreadyWaitlisted
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error requeueing waitlist entry %s", id.Hex())
+		return fmt.Errorf("failed to requeue waitlist entry: %w", err)
+	}
+	return nil
+}
+
+// MarkPromoted links a promoted entry to the booking created for it
+func (r *WaitlistRepository) MarkPromoted(ctx context.Context, id primitive.ObjectID, bookingID primitive.ObjectID) error {
+	_, err := r.Pool.Exec(ctx, "UPDATE waitlist SET booking_id = $2 WHERE id = $1", id.Hex(), bookingID.Hex())
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error marking waitlist entry %s promoted", id.Hex())
+		return fmt.Errorf("failed to mark waitlist entry promoted: %w", err)
+	}
+	return nil
+}
+
+// RemoveWaiting deletes the waiting entries of a class
+func (r *WaitlistRepository) RemoveWaiting(ctx context.Context, classID primitive.ObjectID) (int, error) {
+	tag, err := r.Pool.Exec(ctx, "DELETE FROM waitlist WHERE class_id = $1 AND status = 'waiting'", classID.Hex())
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error removing the waitlist of class %s", classID.Hex())
+		return 0, fmt.Errorf("failed to remove waitlist entries: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,558 @@
+// Package storagetest is a conformance suite for the storage repositories. Every backend
+// runs it, so the service layer can rely on the same semantics whichever one is configured.
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Factory returns the repositories of an empty store. It is called once per test and
+// releases the store through t.Cleanup.
+type Factory func(t *testing.T) *storage.Backend
+
+// Run runs the whole suite against a backend
+func Run(t *testing.T, newBackend Factory) {
+	t.Run("ClassRepository", func(t *testing.T) { RunClassRepositoryTests(t, newBackend) })
+	t.Run("BookingRepository", func(t *testing.T) { RunBookingRepositoryTests(t, newBackend) })
+	t.Run("WaitlistRepository", func(t *testing.T) { RunWaitlistRepositoryTests(t, newBackend) })
+	t.Run("MemberRepository", func(t *testing.T) { RunMemberRepositoryTests(t, newBackend) })
+}
+
+// day returns a date in March 2025
+func day(d int) models.CustomDate {
+	return models.CustomDate(time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC))
+}
+
+// now returns the current time at the millisecond precision every backend keeps
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// after returns the page that follows the last document of a result, as a cursor would
+func after[T any](t *testing.T, page storage.Page, docs []T) storage.Page {
+	t.Helper()
+	require.NotEmpty(t, docs)
+	data, err := bson.Marshal(docs[len(docs)-1])
+	require.NoError(t, err)
+	page.After = bson.Raw(data).Lookup("_id").ObjectID()
+	if page.Sort.Field != "" {
+		page.AfterKey = bson.Raw(data).Lookup(page.Sort.Field)
+	}
+	return page
+}
+
+// collect follows a listing page by page until it runs out, checking every page respects the limit
+func collect[T any](t *testing.T, page storage.Page, list func(storage.Page) ([]T, error)) []T {
+	t.Helper()
+	var all []T
+	for i := 0; ; i++ {
+		require.Less(t, i, 100, "pagination does not terminate")
+		docs, err := list(page)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(docs), page.Limit)
+		all = append(all, docs...)
+		if len(docs) < page.Limit {
+			return all
+		}
+		page = after(t, page, docs)
+	}
+}
+
+func classNames(classes []models.Class) []string {
+	names := []string{}
+	for _, c := range classes {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// RunClassRepositoryTests checks the ClassRepositoryInterface semantics
+func RunClassRepositoryTests(t *testing.T, newBackend Factory) {
+	ctx := context.Background()
+
+	t.Run("stores and replaces classes", func(t *testing.T) {
+		repo := newBackend(t).Classes
+		class := &models.Class{
+			Name: "Yoga", StartDate: day(1), EndDate: day(20), Capacity: 10,
+			StartTime: "07:30", DurationMinutes: 60, Timezone: "Europe/Dublin",
+			Recurrence: &models.RecurrenceRule{Days: []string{"mon", "wed"}, Exceptions: []models.CustomDate{day(17)}},
+		}
+		id, err := repo.Create(ctx, class)
+		require.NoError(t, err)
+		assert.False(t, id.IsZero())
+		class.ID = id
+
+		stored, err := repo.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, class, stored)
+
+		class.Capacity, class.Recurrence = 12, nil
+		assert.NoError(t, repo.Update(ctx, class))
+		stored, err = repo.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, class, stored)
+	})
+
+	t.Run("missing classes are not found", func(t *testing.T) {
+		repo := newBackend(t).Classes
+		id, err := repo.Create(ctx, &models.Class{Name: "Yoga", StartDate: day(1), EndDate: day(1), Capacity: 1})
+		require.NoError(t, err)
+		assert.NoError(t, repo.Delete(ctx, id))
+
+		_, err = repo.GetByID(ctx, id)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, &models.Class{ID: id, Name: "Yoga"}), storage.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, id), storage.ErrNotFound)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		repo := newBackend(t).Classes
+		for _, c := range []models.Class{
+			{Name: "Morning Yoga", StartDate: day(1), EndDate: day(10)},
+			{Name: "Pilates", StartDate: day(5), EndDate: day(15)},
+			{Name: "yoga nidra", StartDate: day(20), EndDate: day(25)},
+			{Name: "Box (advanced)", StartDate: day(1), EndDate: day(31)},
+		} {
+			c.Capacity = 10
+			_, err := repo.Create(ctx, &c)
+			require.NoError(t, err)
+		}
+
+		tests := []struct {
+			name   string
+			filter storage.ClassFilter
+			want   []string
+		}{
+			{"no filter", storage.ClassFilter{}, []string{"Morning Yoga", "Pilates", "yoga nidra", "Box (advanced)"}},
+			{"name is a case-insensitive substring", storage.ClassFilter{Name: "YOGA"}, []string{"Morning Yoga", "yoga nidra"}},
+			{"name is not a pattern", storage.ClassFilter{Name: "(adv"}, []string{"Box (advanced)"}},
+			{"name matches nothing", storage.ClassFilter{Name: "spin"}, []string{}},
+			{"running on or after", storage.ClassFilter{From: ptr(day(15))}, []string{"Pilates", "yoga nidra", "Box (advanced)"}},
+			{"running on or before", storage.ClassFilter{To: ptr(day(5))}, []string{"Morning Yoga", "Pilates", "Box (advanced)"}},
+			{"overlapping a window", storage.ClassFilter{From: ptr(day(11)), To: ptr(day(19))}, []string{"Pilates", "Box (advanced)"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				classes, err := repo.GetAll(ctx, tt.filter, storage.Page{})
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, classNames(classes))
+			})
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		repo := newBackend(t).Classes
+		for i, c := range []models.Class{
+			{Name: "yoga", StartDate: day(3), Capacity: 10},
+			{Name: "Pilates", StartDate: day(1), Capacity: 20},
+			{Name: "Boxing", StartDate: day(3), Capacity: 10},
+			{Name: "Yoga", StartDate: day(2), Capacity: 5},
+			{Name: "Spin", StartDate: day(1), Capacity: 20},
+		} {
+			c.EndDate = day(10 + i)
+			_, err := repo.Create(ctx, &c)
+			require.NoError(t, err)
+		}
+
+		// Ties are broken by creation order whatever the direction
+		tests := []struct {
+			name string
+			sort storage.Sort
+			want []string
+		}{
+			{"in creation order", storage.Sort{}, []string{"yoga", "Pilates", "Boxing", "Yoga", "Spin"}},
+			{"by name ignoring case", storage.Sort{Field: "name"}, []string{"Boxing", "Pilates", "Spin", "yoga", "Yoga"}},
+			{"by name descending", storage.Sort{Field: "name", Desc: true}, []string{"yoga", "Yoga", "Spin", "Pilates", "Boxing"}},
+			{"by start date", storage.Sort{Field: "start_date"}, []string{"Pilates", "Spin", "Yoga", "yoga", "Boxing"}},
+			{"by capacity descending", storage.Sort{Field: "capacity", Desc: true}, []string{"Pilates", "Spin", "yoga", "Boxing", "Yoga"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				for _, limit := range []int{1, 2, 5} {
+					classes := collect(t, storage.Page{Sort: tt.sort, Limit: limit}, func(page storage.Page) ([]models.Class, error) {
+						return repo.GetAll(ctx, storage.ClassFilter{}, page)
+					})
+					assert.Equal(t, tt.want, classNames(classes), "limit %d", limit)
+				}
+			})
+		}
+
+		t.Run("combined with a filter", func(t *testing.T) {
+			classes := collect(t, storage.Page{Sort: storage.Sort{Field: "capacity"}, Limit: 1}, func(page storage.Page) ([]models.Class, error) {
+				return repo.GetAll(ctx, storage.ClassFilter{Name: "yoga"}, page)
+			})
+			assert.Equal(t, []string{"Yoga", "yoga"}, classNames(classes))
+		})
+	})
+}
+
+// RunBookingRepositoryTests checks the BookingRepositoryInterface semantics. Bookings are
+// made on classes that exist, as backends may enforce it.
+func RunBookingRepositoryTests(t *testing.T, newBackend Factory) {
+	ctx := context.Background()
+	date := day(10)
+	newClass := func(t *testing.T, backend *storage.Backend) primitive.ObjectID {
+		id, err := backend.Classes.Create(ctx, &models.Class{Name: "Yoga", StartDate: day(1), EndDate: day(31), Capacity: 10})
+		require.NoError(t, err)
+		return id
+	}
+	newBooking := func(classID, memberID primitive.ObjectID) *models.Booking {
+		return &models.Booking{ClassID: classID, ClassName: "Yoga", MemberID: memberID, MemberName: "Jane",
+			Date: date, Status: models.BookingStatusActive}
+	}
+
+	t.Run("stores bookings", func(t *testing.T) {
+		backend := newBackend(t)
+		startsAt := time.Date(2025, 3, 10, 7, 30, 0, 0, time.UTC)
+		booking := newBooking(newClass(t, backend), primitive.NewObjectID())
+		booking.StartsAt = &startsAt
+
+		id, err := backend.Bookings.Create(ctx, booking, 10)
+		require.NoError(t, err)
+		assert.False(t, id.IsZero())
+		booking.ID = id
+
+		stored, err := backend.Bookings.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, booking, stored)
+
+		_, err = backend.Bookings.GetByID(ctx, primitive.NewObjectID())
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("fills up to capacity", func(t *testing.T) {
+		backend := newBackend(t)
+		classID := newClass(t, backend)
+		for i := 0; i < 3; i++ {
+			_, err := backend.Bookings.Create(ctx, newBooking(classID, primitive.NewObjectID()), 3)
+			require.NoError(t, err)
+		}
+		_, err := backend.Bookings.Create(ctx, newBooking(classID, primitive.NewObjectID()), 3)
+		assert.ErrorIs(t, err, storage.ErrClassFull)
+		_, err = backend.Bookings.Create(ctx, newBooking(newClass(t, backend), primitive.NewObjectID()), 0)
+		assert.ErrorIs(t, err, storage.ErrClassFull)
+
+		// Other dates of the class have their own capacity
+		other := newBooking(classID, primitive.NewObjectID())
+		other.Date = date.AddDays(1)
+		_, err = backend.Bookings.Create(ctx, other, 3)
+		assert.NoError(t, err)
+	})
+
+	t.Run("concurrent bookings never exceed capacity", func(t *testing.T) {
+		backend := newBackend(t)
+		classID := newClass(t, backend)
+
+		results := make(chan error)
+		for i := 0; i < 20; i++ {
+			go func() {
+				_, err := backend.Bookings.Create(ctx, newBooking(classID, primitive.NewObjectID()), 5)
+				results <- err
+			}()
+		}
+		booked := 0
+		for i := 0; i < 20; i++ {
+			if err := <-results; err == nil {
+				booked++
+			} else {
+				assert.ErrorIs(t, err, storage.ErrClassFull)
+			}
+		}
+
+		assert.LessOrEqual(t, booked, 5)
+		counts, err := backend.Bookings.CountBooked(ctx, classID, date, date)
+		assert.NoError(t, err)
+		assert.Equal(t, booked, counts[date.String()])
+		active, err := backend.Bookings.GetActiveByClass(ctx, classID, date)
+		assert.NoError(t, err)
+		assert.Len(t, active, booked)
+	})
+
+	t.Run("a member books a class and date once", func(t *testing.T) {
+		backend := newBackend(t)
+		classID, memberID := newClass(t, backend), primitive.NewObjectID()
+
+		_, err := backend.Bookings.Create(ctx, newBooking(classID, memberID), 1)
+		require.NoError(t, err)
+
+		// Already booked wins over full
+		_, err = backend.Bookings.Create(ctx, newBooking(classID, memberID), 1)
+		assert.ErrorIs(t, err, storage.ErrAlreadyBooked)
+		_, err = backend.Bookings.Create(ctx, newBooking(classID, memberID), 5)
+		assert.ErrorIs(t, err, storage.ErrAlreadyBooked)
+
+		other := newBooking(classID, memberID)
+		other.Date = date.AddDays(1)
+		_, err = backend.Bookings.Create(ctx, other, 5)
+		assert.NoError(t, err)
+
+		// Bookings made before members existed are not checked
+		for i := 0; i < 2; i++ {
+			_, err = backend.Bookings.Create(ctx, newBooking(classID, primitive.NilObjectID), 5)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("cancelling releases the spot once", func(t *testing.T) {
+		backend := newBackend(t)
+		classID, memberID := newClass(t, backend), primitive.NewObjectID()
+		id, err := backend.Bookings.Create(ctx, newBooking(classID, memberID), 1)
+		require.NoError(t, err)
+
+		cancelled, err := backend.Bookings.Cancel(ctx, id, "Feeling unwell")
+		require.NoError(t, err)
+		assert.Equal(t, id, cancelled.ID)
+		assert.Equal(t, models.BookingStatusCancelled, cancelled.Status)
+		assert.Equal(t, "Feeling unwell", cancelled.CancellationReason)
+		assert.NotNil(t, cancelled.CancelledAt)
+
+		_, err = backend.Bookings.Cancel(ctx, id, "")
+		assert.ErrorIs(t, err, storage.ErrAlreadyCancelled)
+		_, err = backend.Bookings.Cancel(ctx, primitive.NewObjectID(), "")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		counts, err := backend.Bookings.CountBooked(ctx, classID, date, date)
+		assert.NoError(t, err)
+		assert.Zero(t, counts[date.String()])
+		active, err := backend.Bookings.GetActiveByClass(ctx, classID, date)
+		assert.NoError(t, err)
+		assert.Empty(t, active)
+
+		// The member can book again and the history is kept
+		_, err = backend.Bookings.Create(ctx, newBooking(classID, memberID), 1)
+		assert.NoError(t, err)
+		all, err := backend.Bookings.GetAll(ctx, storage.BookingFilter{ClassID: classID}, storage.Page{})
+		assert.NoError(t, err)
+		assert.Len(t, all, 2)
+	})
+
+	t.Run("counts booked spots per date", func(t *testing.T) {
+		backend := newBackend(t)
+		classID := newClass(t, backend)
+		for _, offset := range []int{0, 0, 1, 3} {
+			booking := newBooking(classID, primitive.NewObjectID())
+			booking.Date = date.AddDays(offset)
+			_, err := backend.Bookings.Create(ctx, booking, 10)
+			require.NoError(t, err)
+		}
+
+		counts, err := backend.Bookings.CountBooked(ctx, classID, date.AddDays(1), date.AddDays(3))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"2025-03-11": 1, "2025-03-13": 1}, counts)
+		counts, err = backend.Bookings.CountBooked(ctx, newClass(t, backend), date, date)
+		assert.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("filters and pages", func(t *testing.T) {
+		backend := newBackend(t)
+		yoga, pilates := newClass(t, backend), newClass(t, backend)
+		jane, john := primitive.NewObjectID(), primitive.NewObjectID()
+		var ids []primitive.ObjectID
+		for _, b := range []struct {
+			classID, memberID primitive.ObjectID
+			name              string
+			offset            int
+		}{
+			{yoga, jane, "Jane", 0},
+			{yoga, john, "John", 0},
+			{pilates, jane, "Jane", 1},
+			{yoga, jane, "Jane", 2},
+			{pilates, primitive.NilObjectID, "JANE", 2},
+		} {
+			booking := newBooking(b.classID, b.memberID)
+			booking.MemberName, booking.Date = b.name, date.AddDays(b.offset)
+			id, err := backend.Bookings.Create(ctx, booking, 10)
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		_, err := backend.Bookings.Cancel(ctx, ids[3], "")
+		require.NoError(t, err)
+
+		tests := []struct {
+			name   string
+			filter storage.BookingFilter
+			want   []primitive.ObjectID
+		}{
+			{"no filter", storage.BookingFilter{}, ids},
+			{"class", storage.BookingFilter{ClassID: pilates}, []primitive.ObjectID{ids[2], ids[4]}},
+			{"member", storage.BookingFilter{MemberID: jane}, []primitive.ObjectID{ids[0], ids[2], ids[3]}},
+			{"member name ignoring case", storage.BookingFilter{MemberName: "jane"}, []primitive.ObjectID{ids[0], ids[2], ids[3], ids[4]}},
+			{"member name is not a pattern", storage.BookingFilter{MemberName: "J.*"}, []primitive.ObjectID{}},
+			{"date", storage.BookingFilter{Date: ptr(date)}, []primitive.ObjectID{ids[0], ids[1]}},
+			{"date window", storage.BookingFilter{From: ptr(date.AddDays(1)), To: ptr(date.AddDays(2))}, ids[2:]},
+			{"status", storage.BookingFilter{Status: models.BookingStatusCancelled}, []primitive.ObjectID{ids[3]}},
+			{"combined", storage.BookingFilter{ClassID: yoga, MemberID: jane, Status: models.BookingStatusActive}, []primitive.ObjectID{ids[0]}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				for _, limit := range []int{1, 2, 10} {
+					bookings := collect(t, storage.Page{Limit: limit}, func(page storage.Page) ([]models.Booking, error) {
+						return backend.Bookings.GetAll(ctx, tt.filter, page)
+					})
+					got := []primitive.ObjectID{}
+					for _, b := range bookings {
+						got = append(got, b.ID)
+					}
+					assert.Equal(t, tt.want, got, "limit %d", limit)
+				}
+			})
+		}
+
+		active, err := backend.Bookings.GetActiveByClass(ctx, yoga, date)
+		assert.NoError(t, err)
+		require.Len(t, active, 2)
+		assert.Equal(t, ids[0], active[0].ID)
+		assert.Equal(t, ids[1], active[1].ID)
+		// Bookings dated before from are left out
+		active, err = backend.Bookings.GetActiveByClass(ctx, yoga, date.AddDays(1))
+		assert.NoError(t, err)
+		assert.Empty(t, active)
+		active, err = backend.Bookings.GetActiveByClass(ctx, pilates, date.AddDays(2))
+		assert.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, ids[4], active[0].ID)
+	})
+}
+
+// RunWaitlistRepositoryTests checks the WaitlistRepositoryInterface semantics
+func RunWaitlistRepositoryTests(t *testing.T, newBackend Factory) {
+	ctx := context.Background()
+	backend := newBackend(t)
+	date := day(10)
+	classID, err := backend.Classes.Create(ctx, &models.Class{Name: "Yoga", StartDate: date, EndDate: date, Capacity: 1})
+	require.NoError(t, err)
+
+	var ids []primitive.ObjectID
+	for i, name := range []string{"Jane", "John", "Mary"} {
+		id, position, err := backend.Waitlist.Add(ctx, &models.WaitlistEntry{
+			ClassID: classID, Date: date, MemberID: primitive.NewObjectID(), MemberName: name,
+			Status: models.WaitlistStatusWaiting, CreatedAt: now(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, i+1, position)
+		ids = append(ids, id)
+	}
+
+	next, err := backend.Waitlist.PopNext(ctx, classID, date)
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], next.ID)
+	assert.Equal(t, models.WaitlistStatusPromoted, next.Status)
+
+	entries, err := backend.Waitlist.List(ctx, classID, date)
+	assert.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, []int{1, 2}, []int{entries[0].Position, entries[1].Position})
+	assert.Equal(t, "John", entries[0].MemberName)
+
+	// A requeued entry gets its place back
+	require.NoError(t, backend.Waitlist.Requeue(ctx, ids[0]))
+	entries, err = backend.Waitlist.List(ctx, classID, date)
+	assert.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, ids[0], entries[0].ID)
+
+	// A member waits once per occurrence
+	_, _, err = backend.Waitlist.Add(ctx, &models.WaitlistEntry{
+		ClassID: classID, Date: date, MemberID: entries[1].MemberID, MemberName: "John",
+		Status: models.WaitlistStatusWaiting, CreatedAt: now(),
+	})
+	assert.ErrorIs(t, err, storage.ErrAlreadyWaitlisted)
+
+	for range ids {
+		_, err := backend.Waitlist.PopNext(ctx, classID, date)
+		require.NoError(t, err)
+	}
+	_, err = backend.Waitlist.PopNext(ctx, classID, date)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = backend.Waitlist.PopNext(ctx, classID, date.AddDays(1))
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.NoError(t, backend.Waitlist.MarkPromoted(ctx, ids[0], primitive.NewObjectID()))
+
+	// A promoted member may wait again, the promoted entry then cannot be requeued
+	_, position, err := backend.Waitlist.Add(ctx, &models.WaitlistEntry{
+		ClassID: classID, Date: date, MemberID: entries[0].MemberID, MemberName: "Jane",
+		Status: models.WaitlistStatusWaiting, CreatedAt: now(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, position)
+	assert.ErrorIs(t, backend.Waitlist.Requeue(ctx, ids[0]), storage.ErrAlreadyWaitlisted)
+
+	// Removing the waitlist of a class leaves its promoted entries and other classes alone
+	otherID, err := backend.Classes.Create(ctx, &models.Class{Name: "Pilates", StartDate: date, EndDate: date, Capacity: 1})
+	require.NoError(t, err)
+	_, _, err = backend.Waitlist.Add(ctx, &models.WaitlistEntry{
+		ClassID: otherID, Date: date, MemberID: primitive.NewObjectID(), MemberName: "Anna",
+		Status: models.WaitlistStatusWaiting, CreatedAt: now(),
+	})
+	require.NoError(t, err)
+	removed, err := backend.Waitlist.RemoveWaiting(ctx, classID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	entries, err = backend.Waitlist.List(ctx, classID, date)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoError(t, backend.Waitlist.Requeue(ctx, ids[1]))
+	entries, err = backend.Waitlist.List(ctx, otherID, date)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+// RunMemberRepositoryTests checks the MemberRepositoryInterface semantics
+func RunMemberRepositoryTests(t *testing.T, newBackend Factory) {
+	ctx := context.Background()
+	repo := newBackend(t).Members
+	newMember := func(name, email string) *models.Member {
+		return &models.Member{Name: name, Email: email, Status: models.MemberStatusActive, CreatedAt: now()}
+	}
+
+	jane := newMember("Jane Doe", "jane@example.com")
+	id, err := repo.Create(ctx, jane)
+	require.NoError(t, err)
+	jane.ID = id
+	stored, err := repo.GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, jane, stored)
+
+	johnID, err := repo.Create(ctx, newMember("John Doe", "john@example.com"))
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, newMember("Janet", "jane@example.com"))
+	assert.ErrorIs(t, err, storage.ErrEmailTaken)
+	john := newMember("John Doe", "jane@example.com")
+	john.ID = johnID
+	assert.ErrorIs(t, repo.Update(ctx, john), storage.ErrEmailTaken)
+
+	jane.Status = models.MemberStatusInactive
+	assert.NoError(t, repo.Update(ctx, jane))
+	stored, err = repo.GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, jane, stored)
+
+	members, err := repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	assert.NoError(t, repo.Delete(ctx, id))
+	_, err = repo.GetByID(ctx, id)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, id), storage.ErrNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, jane), storage.ErrNotFound)
+
+	// The email of a deleted member can be used again
+	_, err = repo.Create(ctx, newMember("Jane Roe", "jane@example.com"))
+	assert.NoError(t, err)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,112 @@
+package storagetest
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	Run(t, func(t *testing.T) *storage.Backend {
+		return storage.NewMemoryBackend(storage.NewMemoryStore())
+	})
+}
+
+// newMongoClient connects to the MongoDB server at MONGO_URI, skipping the test when it is unset
+func newMongoClient(t *testing.T) *mongo.Client {
+	t.Helper()
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	require.NoError(t, client.Ping(ctx, nil))
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+	return client
+}
+
+// newMongoRepository returns a throwaway database of the client, dropped when the test ends
+func newMongoRepository(t *testing.T, client *mongo.Client) *storage.MongoRepository {
+	db := client.Database("storagetest_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() { _ = db.Drop(context.Background()) })
+	return &storage.MongoRepository{Client: client, Database: db, Collections: storage.DefaultCollections}
+}
+
+// TestMongoBackend runs the suite against the MongoDB server at MONGO_URI, each test in a
+// throwaway database dropped when it ends
+func TestMongoBackend(t *testing.T) {
+	client := newMongoClient(t)
+	Run(t, func(t *testing.T) *storage.Backend {
+		m := newMongoRepository(t, client)
+		_, err := storage.EnsureIndexes(context.Background(), m)
+		require.NoError(t, err)
+		return storage.NewMongoBackend(m)
+	})
+}
+
+// TestMongoOccurrenceSeed checks bookings stored before the occurrence counter existed take their spots
+func TestMongoOccurrenceSeed(t *testing.T) {
+	ctx := context.Background()
+	m := newMongoRepository(t, newMongoClient(t))
+	backend := storage.NewMongoBackend(m)
+	classID, date := primitive.NewObjectID(), day(10)
+
+	_, err := m.Collection(m.Collections.Bookings).InsertMany(ctx, []interface{}{
+		models.Booking{ID: primitive.NewObjectID(), ClassID: classID, MemberName: "Jane", Date: date, Status: models.BookingStatusActive},
+		// Legacy bookings have no status and are active
+		bson.M{"_id": primitive.NewObjectID(), "class_id": classID, "member_name": "John", "date": date},
+		models.Booking{ID: primitive.NewObjectID(), ClassID: classID, MemberName: "Mary", Date: date, Status: models.BookingStatusCancelled},
+	})
+	require.NoError(t, err)
+
+	_, err = backend.Bookings.Create(ctx, &models.Booking{ClassID: classID, MemberName: "Anna", Date: date, Status: models.BookingStatusActive}, 3)
+	require.NoError(t, err)
+	_, err = backend.Bookings.Create(ctx, &models.Booking{ClassID: classID, MemberName: "Paul", Date: date, Status: models.BookingStatusActive}, 3)
+	assert.ErrorIs(t, err, storage.ErrClassFull)
+
+	booked, err := backend.Bookings.CountBooked(ctx, classID, date, date)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{date.String(): 3}, booked)
+}
+
+func TestMongoEnsureIndexes(t *testing.T) {
+	ctx := context.Background()
+	m := newMongoRepository(t, newMongoClient(t))
+
+	report, err := storage.EnsureIndexes(ctx, m)
+	require.NoError(t, err)
+	assert.NotEmpty(t, report.Created)
+	assert.Empty(t, report.Rebuilt)
+
+	// A second run finds everything in place
+	again, err := storage.EnsureIndexes(ctx, m)
+	require.NoError(t, err)
+	assert.Empty(t, again.Created)
+	assert.Empty(t, again.Rebuilt)
+	assert.ElementsMatch(t, report.Created, again.Unchanged)
+
+	// An index whose definition drifted is rebuilt
+	_, err = m.Collection(m.Collections.Bookings).Indexes().DropOne(ctx, "class_date")
+	require.NoError(t, err)
+	_, err = m.Collection(m.Collections.Bookings).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "class_id", Value: 1}},
+		Options: options.Index().SetName("class_date"),
+	})
+	require.NoError(t, err)
+	again, err = storage.EnsureIndexes(ctx, m)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bookings.class_date"}, again.Rebuilt)
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WaitlistRepositoryInterface defines the contract for WaitlistRepository
+type WaitlistRepositoryInterface interface {
+	// Add queues an entry and returns its ID and 1-based position on the waitlist. It returns
+	// ErrAlreadyWaitlisted if the member is already waiting for the class occurrence.
+	Add(ctx context.Context, entry *models.WaitlistEntry) (primitive.ObjectID, int, error)
+	// List returns the waiting entries of a class occurrence in queue order.
+	List(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) ([]models.WaitlistEntry, error)
+	// PopNext marks the first waiting entry as promoted and returns it, or ErrNotFound if the queue is empty.
+	PopNext(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) (*models.WaitlistEntry, error)
+	// Requeue puts a popped entry back in its original place in the queue. It returns
+	// ErrAlreadyWaitlisted if the member joined the queue again meanwhile.
+	Requeue(ctx context.Context, id primitive.ObjectID) error
+	// MarkPromoted records the booking created for a promoted entry.
+	MarkPromoted(ctx context.Context, id primitive.ObjectID, bookingID primitive.ObjectID) error
+	// RemoveWaiting deletes the waiting entries of a class on every date and returns how many
+	// it deleted. Promoted entries are kept with their bookings.
+	RemoveWaiting(ctx context.Context, classID primitive.ObjectID) (int, error)
+}
+
+// WaitlistRepository struct for MongoDB
+type WaitlistRepository struct {
+	Collection *mongo.Collection
+}
+
+// NewWaitlistRepository initializes a WaitlistRepository with MongoDB collection
+func NewWaitlistRepository(collection *mongo.Collection) WaitlistRepositoryInterface {
+	return &WaitlistRepository{Collection: collection}
+}
+
+// waitingFilter matches the waiting entries of a class occurrence
+func waitingFilter(classID primitive.ObjectID, date models.CustomDate) bson.M {
+	return bson.M{"class_id": classID, "date": date, "status": models.WaitlistStatusWaiting}
+}
+
+// Add inserts a waiting entry into the MongoDB collection. Entries are queued in _id order.
+func (r *WaitlistRepository) Add(ctx context.Context, entry *models.WaitlistEntry) (primitive.ObjectID, int, error) {
+	entry.ID = primitive.NewObjectID()
+	_, err := r.Collection.InsertOne(ctx, entry)
+	if mongo.IsDuplicateKeyError(err) {
+		return primitive.NilObjectID, 0, ErrAlreadyWaitlisted
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error inserting waitlist entry")
+		return primitive.NilObjectID, 0, fmt.Errorf("failed to insert waitlist entry: %w", err)
+	}
+
+	filter := waitingFilter(entry.ClassID, entry.Date)
+	filter["_id"] = bson.M{"$lte": entry.ID}
+	position, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error counting waitlist position")
+		return primitive.NilObjectID, 0, fmt.Errorf("failed to count waitlist position: %w", err)
+	}
+	log.Info().Ctx(ctx).Msgf("Inserted waitlist entry with ID: %v at position %d", entry.ID, position)
+	return entry.ID, int(position), nil
+}
+
+// List retrieves the waiting entries of a class occurrence ordered by queue position
+func (r *WaitlistRepository) List(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) ([]models.WaitlistEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.Collection.Find(ctx, waitingFilter(classID, date), opts)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error finding waitlist entries")
+		return nil, fmt.Errorf("failed to find waitlist entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.WaitlistEntry
+	for cursor.Next(ctx) {
+		var entry models.WaitlistEntry
+		if err := cursor.Decode(&entry); err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("Error decoding waitlist entry")
+			return nil, fmt.Errorf("failed to decode waitlist entry: %w", err)
+		}
+		entry.Position = len(entries) + 1
+		entries = append(entries, entry)
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Cursor error")
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// PopNext atomically claims the first waiting entry of a class occurrence
+func (r *WaitlistRepository) PopNext(ctx context.Context, classID primitive.ObjectID, date models.CustomDate) (*models.WaitlistEntry, error) {
+	update := bson.M{"$set": bson.M{"status": models.WaitlistStatusPromoted}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var entry models.WaitlistEntry
+	err := r.Collection.FindOneAndUpdate(ctx, waitingFilter(classID, date), update, opts).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("Error popping waitlist entry")
+		return nil, fmt.Errorf("failed to pop waitlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// Requeue sets a promoted entry back to waiting. Its _id keeps its original queue position.
+func (r *WaitlistRepository) Requeue(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{"$set": bson.M{"status": models.WaitlistStatusWaiting}}
+	_, err := r.Collection.UpdateByID(ctx, id, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrAlreadyWaitlisted
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error requeueing waitlist entry %s", id.Hex())
+		return fmt.Errorf("failed to requeue waitlist entry: %w", err)
+	}
+	return nil
+}
+
+// MarkPromoted links a promoted entry to the booking created for it
+func (r *WaitlistRepository) MarkPromoted(ctx context.Context, id primitive.ObjectID, bookingID primitive.ObjectID) error {
+	update := bson.M{"$set": bson.M{"booking_id": bookingID}}
+	if _, err := r.Collection.UpdateByID(ctx, id, update); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error marking waitlist entry %s promoted", id.Hex())
+		return fmt.Errorf("failed to mark waitlist entry promoted: %w", err)
+	}
+	return nil
+}
+
+// RemoveWaiting deletes the waiting entries of a class from the MongoDB collection
+func (r *WaitlistRepository) RemoveWaiting(ctx context.Context, classID primitive.ObjectID) (int, error) {
+	result, err := r.Collection.DeleteMany(ctx, bson.M{"class_id": classID, "status": models.WaitlistStatusWaiting})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msgf("Error removing the waitlist of class %s", classID.Hex())
+		return 0, fmt.Errorf("failed to remove waitlist entries: %w", err)
+	}
+	return int(result.DeletedCount), nil
+}
+
+// RemoveDuplicateWaitlistEntries deletes the entries of members waiting more than once for a
+// class occurrence, keeping the earliest one, and returns how many it deleted
+func RemoveDuplicateWaitlistEntries(ctx context.Context, m *MongoRepository) (int64, error) {
+	collection := m.Collection(m.Collections.Waitlist)
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": models.WaitlistStatusWaiting}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"member_id": "$member_id", "class_id": "$class_id", "date": "$date"},
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to find duplicate waitlist entries: %w", err)
+	}
+	var groups []struct {
+		IDs []primitive.ObjectID `bson:"ids"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return 0, fmt.Errorf("failed to read duplicate waitlist entries: %w", err)
+	}
+
+	var duplicates []primitive.ObjectID
+	for _, group := range groups {
+		duplicates = append(duplicates, group.IDs[1:]...)
+	}
+	if len(duplicates) == 0 {
+		return 0, nil
+	}
+	result, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": duplicates}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete duplicate waitlist entries: %w", err)
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The trace context of a caller, as sent in the traceparent header
+const (
+	callerTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	callerSpanID      = "00f067aa0ba902b7"
+	callerTraceparent = "00-" + callerTraceID + "-" + callerSpanID + "-01"
+)
+
+// record installs a tracer provider recording the spans in memory for the duration of the test
+func record(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	_, err := Setup(context.Background(), Config{Exporter: ExporterNone}, "test")
+	require.NoError(t, err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+// attr returns the value of an attribute of a span
+func attr(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestMiddleware(t *testing.T) {
+	exporter := record(t)
+
+	r := chi.NewRouter()
+	r.Use(Middleware(func(r *http.Request) string { return "GetClass" }))
+	var handlerSpan trace.SpanContext
+	r.Get("/classes/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusNotFound)
+	})
+	r.Get("/fail", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/classes/67eacd9f4aed3932a6d966a3", nil)
+	req.Header.Set("traceparent", callerTraceparent)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GetClass", span.Name)
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+	// The span continues the trace of the caller and is the one the handler sees
+	assert.Equal(t, callerTraceID, span.SpanContext.TraceID().String())
+	assert.Equal(t, callerSpanID, span.Parent.SpanID().String())
+	assert.True(t, span.Parent.IsRemote())
+	assert.Equal(t, span.SpanContext.SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, int64(http.StatusNotFound), attr(span, semconv.HTTPResponseStatusCodeKey).AsInt64())
+	assert.Equal(t, "/classes/{id}", attr(span, semconv.HTTPRouteKey).AsString())
+	assert.Equal(t, codes.Unset, span.Status.Code)
+
+	// Without a caller a new trace starts, and server errors mark the span failed
+	exporter.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	spans = exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.False(t, spans[0].Parent.IsValid())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+func TestMongoMonitor(t *testing.T) {
+	exporter := record(t)
+	monitor := MongoMonitor()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "BookClass")
+	command, _ := bson.Marshal(bson.D{{Key: "insert", Value: "bookings"}})
+	connection := "localhost:27017[-1]"
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, CommandName: "insert", DatabaseName: "glofox", RequestID: 1, ConnectionID: connection})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", RequestID: 1, ConnectionID: connection}})
+	parent.End()
+
+	// The command span is a child of the span the repository was called in
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "bookings.insert", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext.TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+}
+
+func TestLogHook(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(LogHook{})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	logger.Info().Ctx(trace.ContextWithSpanContext(context.Background(), sc)).Msg("Booking created")
+
+	var line map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, callerTraceID, line["trace_id"])
+	assert.Equal(t, callerSpanID, line["span_id"])
+
+	// Log lines outside a trace are left alone
+	buf.Reset()
+	logger.Info().Ctx(context.Background()).Msg("Server started")
+	assert.NotContains(t, buf.String(), "trace_id")
+}
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterStdout, ServiceName: "glofox-backend", SampleRatio: 1}, "1.4.0")
+	require.NoError(t, err)
+	assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), Config{Exporter: "jaeger"}, "1.4.0")
+	assert.EqualError(t, err, `unknown trace exporter "jaeger"`)
+}
@@ -1,27 +1,156 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Embed the timezone database for class recurrence rules, the runtime image has none
 
-	"github.com/joho/godotenv"
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
+	"github.com/sinhaseemant/glofox-backend/internal/config"
+	"github.com/sinhaseemant/glofox-backend/internal/health"
+	"github.com/sinhaseemant/glofox-backend/internal/metrics"
 	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/internal/storage/postgres"
+	"github.com/sinhaseemant/glofox-backend/internal/tracing"
 	"github.com/sinhaseemant/glofox-backend/routes"
 )
 
+// version is the build version reported by the probes, set with -ldflags "-X main.version=..."
+var version = "dev"
+
 func main() {
-	err := godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	zerolog.SetGlobalLevel(cfg.Level())
+	zlog.Logger = zlog.Logger.Hook(tracing.LogHook{})
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, version)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	backend, err := openBackend(cfg)
 	if err != nil {
-		log.Println("Warning: No .env file found")
+		log.Fatalf("Failed to open storage backend: %v", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := migrate(backend, os.Args[2:])
+		backend.Close(context.Background())
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	if backend.Mongo != nil {
+		// Upgrade the documents stored by earlier versions, then create the indexes the repositories rely on
+		if _, err := storage.NewMigrator(backend.Mongo).Up(context.Background(), 0); err != nil {
+			log.Fatalf("Failed to migrate: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		_, err = storage.EnsureIndexes(ctx, backend.Mongo)
+		cancel()
+		if err != nil {
+			log.Fatalf("Failed to create indexes: %v", err)
+		}
+	}
+	checks := map[string]health.Check{}
+	if backend.Pinger != nil {
+		checks[cfg.Storage] = backend.Ping
+	}
+	probes := health.NewProbes(version, checks)
+	router := routes.NewRouter(backend, routes.Options{AllowedOrigins: cfg.CORS.AllowedOrigins, Probes: probes})
+
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           router,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
 	}
+	err = serve(server, probes, cfg.Server)
 
-	// Connect to MongoDB
-	mr, err := storage.NewMongoRepository()
+	// Disconnect once no request uses the storage anymore
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := backend.Close(ctx); err != nil {
+		log.Printf("Error closing storage backend: %v", err)
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Error exporting the last spans: %v", err)
+	}
+	cancel()
 	if err != nil {
-		log.Fatalf("Failed to connect to MongoDB: %v", err)
+		log.Fatalf("Server failed: %v", err)
+	}
+	log.Println("Server stopped")
+}
+
+// serve runs the server until SIGTERM or SIGINT. It then reports itself not ready for the
+// shutdown delay, stops accepting connections and gives the requests in flight up to the
+// shutdown timeout to finish.
+func serve(server *http.Server, probes *health.Probes, cfg config.Server) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("Server is listening on %s", server.Addr)
+		errs <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
 	}
-	router := routes.NewRouter(mr)
+	stop() // A second signal kills the process at once
 
-	log.Println("Server is running on port 8080")
-	log.Fatal(http.ListenAndServe(":8080", router))
+	probes.Drain()
+	if cfg.ShutdownDelay > 0 {
+		log.Printf("Shutting down, reporting not ready for %s", cfg.ShutdownDelay)
+		time.Sleep(cfg.ShutdownDelay)
+	}
+	log.Printf("Shutting down, waiting up to %s for requests in flight", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("requests still in flight after %s: %w", cfg.ShutdownTimeout, err)
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// openBackend opens the configured storage backend
+func openBackend(cfg config.Config) (*storage.Backend, error) {
+	switch cfg.Storage {
+	case storage.BackendMongo:
+		mr, err := storage.NewMongoRepository(cfg.Mongo, metrics.MongoMonitor(), tracing.MongoMonitor())
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+		}
+		return storage.NewMongoBackend(mr), nil
+	case storage.BackendMemory:
+		log.Println("Warning: Using in-memory storage, data is lost on exit")
+		return storage.NewMemoryBackend(storage.NewMemoryStore()), nil
+	case storage.BackendPostgres:
+		// Connecting also applies the pending schema migrations
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return postgres.NewBackend(ctx, cfg.Postgres.URL)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage)
+	}
 }
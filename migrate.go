@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/sinhaseemant/glofox-backend/internal/storage"
+)
+
+const migrateUsage = "usage: migrate up [version] | down [steps] | status"
+
+// migrate runs the migrate subcommand: up applies the pending migrations, up to a version
+// if given, down reverts the last steps migrations, one by default, and status lists them
+func migrate(backend *storage.Backend, args []string) error {
+	if backend.Mongo == nil {
+		return errors.New("migrate needs the mongo storage backend")
+	}
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New(migrateUsage)
+	}
+	number := func(def int) (int, error) {
+		if len(args) < 2 {
+			return def, nil
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("%q is not a positive number, %s", args[1], migrateUsage)
+		}
+		return n, nil
+	}
+
+	ctx := context.Background()
+	migrator := storage.NewMigrator(backend.Mongo)
+	switch args[0] {
+	case "up":
+		target, err := number(0)
+		if err != nil {
+			return err
+		}
+		applied, err := migrator.Up(ctx, target)
+		fmt.Printf("Applied %d migrations\n", len(applied))
+		return err
+	case "down":
+		steps, err := number(1)
+		if err != nil {
+			return err
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		fmt.Printf("Reverted %d migrations\n", len(reverted))
+		return err
+	case "status":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tAPPLIED\tDESCRIPTION")
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.Unknown {
+				applied += " (unknown to this version)"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, applied, status.Description)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -2,9 +2,12 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -12,12 +15,20 @@ type CustomDate time.Time
 
 const customDateFormat = "2006-01-02"
 
-// UnmarshalJSON allows CustomDate to be parsed from "YYYY-MM-DD"
+// UnmarshalJSON allows CustomDate to be parsed from "YYYY-MM-DD". RFC 3339 timestamps
+// are accepted too, keeping the calendar day as written in their own offset.
 func (cd *CustomDate) UnmarshalJSON(b []byte) error {
 	str := strings.Trim(string(b), `"`) // Remove quotes from JSON string
+	if str == "null" {
+		return nil
+	}
 	t, err := time.Parse(customDateFormat, str)
 	if err != nil {
-		return err
+		ts, tsErr := time.Parse(time.RFC3339, str)
+		if tsErr != nil {
+			return err
+		}
+		t = time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
 	}
 	*cd = CustomDate(t)
 	return nil
@@ -28,6 +39,42 @@ func (cd CustomDate) MarshalJSON() ([]byte, error) {
 	return json.Marshal(time.Time(cd).Format(customDateFormat))
 }
 
+// MarshalBSONValue stores CustomDate as a BSON date at midnight UTC so it can be
+// range-queried and indexed. A zero date is stored as null.
+func (cd CustomDate) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if cd.IsZero() {
+		return bson.TypeNull, nil, nil
+	}
+	return bson.MarshalValue(cd.UTC().ToTime())
+}
+
+// UnmarshalBSONValue reads a CustomDate from a BSON date. "YYYY-MM-DD" strings are
+// accepted too. Documents written before dates had a BSON encoding hold an empty
+// document instead, which carries no date and decodes as a zero date.
+func (cd *CustomDate) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bson.TypeDateTime:
+		*cd = CustomDate(raw.Time().UTC()).UTC()
+	case bson.TypeString:
+		parsed, err := time.Parse(customDateFormat, raw.StringValue())
+		if err != nil {
+			return err
+		}
+		*cd = CustomDate(parsed)
+	case bson.TypeNull, bson.TypeUndefined:
+		*cd = CustomDate{}
+	case bson.TypeEmbeddedDocument:
+		if elems, err := raw.Document().Elements(); err != nil || len(elems) > 0 {
+			return fmt.Errorf("cannot decode BSON document %s into a date", raw.Document())
+		}
+		*cd = CustomDate{}
+	default:
+		return fmt.Errorf("cannot decode BSON %s into a date", t)
+	}
+	return nil
+}
+
 // ToTime converts CustomDate to a standard time.Time object
 func (cd CustomDate) ToTime() time.Time {
 	return time.Time(cd)
@@ -38,20 +85,112 @@ func (cd CustomDate) IsZero() bool {
 	return cd.ToTime().IsZero()
 }
 
+// String formats the CustomDate as "YYYY-MM-DD"
+func (cd CustomDate) String() string {
+	return cd.ToTime().Format(customDateFormat)
+}
+
+// Weekday returns the day of the week of the date
+func (cd CustomDate) Weekday() time.Weekday {
+	return cd.ToTime().Weekday()
+}
+
+// UTC returns the calendar day of the date at midnight UTC
+func (cd CustomDate) UTC() CustomDate {
+	return cd.AddDays(0)
+}
+
+// AddDays returns the calendar day n days after the date, at midnight UTC
+func (cd CustomDate) AddDays(n int) CustomDate {
+	t := cd.ToTime()
+	return CustomDate(time.Date(t.Year(), t.Month(), t.Day()+n, 0, 0, 0, 0, time.UTC))
+}
+
+// Within reports whether the date falls between start and end, inclusive.
+// Only the calendar day is compared, the time of day is ignored.
+func (cd CustomDate) Within(start, end CustomDate) bool {
+	day := cd.String()
+	return day >= start.String() && day <= end.String()
+}
+
 // Class represents a class with its details.
 type Class struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"` // MongoDB ObjectID
-	Name      string             `bson:"name" json:"name"`
-	StartDate CustomDate         `bson:"start_date" json:"start_date"`
-	EndDate   CustomDate         `bson:"end_date" json:"end_date"`
-	Capacity  int                `bson:"capacity" json:"capacity"`
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"` // MongoDB ObjectID
+	Name            string             `bson:"name" json:"name"`
+	StartDate       CustomDate         `bson:"start_date" json:"start_date"`
+	EndDate         CustomDate         `bson:"end_date" json:"end_date"`
+	Capacity        int                `bson:"capacity" json:"capacity"`
+	StartTime       string             `bson:"start_time,omitempty" json:"start_time,omitempty"`             // Local start time of each session as "HH:MM"
+	DurationMinutes int                `bson:"duration_minutes,omitempty" json:"duration_minutes,omitempty"` // Length of each session
+	Timezone        string             `bson:"timezone,omitempty" json:"timezone,omitempty"`                 // IANA timezone of StartTime, e.g. "Europe/Dublin"
+	Recurrence      *RecurrenceRule    `bson:"recurrence,omitempty" json:"recurrence,omitempty"`             // Weekly schedule; nil means the class runs every day
+}
+
+// IsActiveOn reports whether the class runs on the given date
+func (c Class) IsActiveOn(date CustomDate) bool {
+	return date.Within(c.StartDate, c.EndDate)
 }
 
+// Booking statuses. Bookings stored before statuses existed have an empty status and are active.
+const (
+	BookingStatusActive    = "active"
+	BookingStatusCancelled = "cancelled"
+)
+
 // Booking represents a member's booking for a specific class on a specific date.
 type Booking struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`        // MongoDB ObjectID
-	ClassName  string             `bson:"class_name" json:"class_name"`   // Name of the class booked
-	MemberName string             `bson:"member_name" json:"member_name"` // Name of the member
-	Date       CustomDate         `bson:"date" json:"date"`               // Specific date of the booking
-	ClassID    primitive.ObjectID `bson:"class_id" json:"class_id"`       // Reference to the class definition
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`                                            // MongoDB ObjectID
+	ClassName          string             `bson:"class_name" json:"class_name"`                                       // Name of the class booked
+	MemberID           primitive.ObjectID `bson:"member_id,omitempty" json:"member_id,omitempty"`                     // Reference to the member, unset on bookings made before members existed
+	MemberName         string             `bson:"member_name" json:"member_name"`                                     // Name of the member
+	Date               CustomDate         `bson:"date" json:"date"`                                                   // Specific date of the booking
+	ClassID            primitive.ObjectID `bson:"class_id" json:"class_id"`                                           // Reference to the class definition
+	StartsAt           *time.Time         `bson:"starts_at,omitempty" json:"starts_at,omitempty"`                     // Start of the booked session, for classes with a start time
+	Status             string             `bson:"status" json:"status"`                                               // active or cancelled
+	CancelledAt        *time.Time         `bson:"cancelled_at,omitempty" json:"cancelled_at,omitempty"`               // When the booking was cancelled
+	CancellationReason string             `bson:"cancellation_reason,omitempty" json:"cancellation_reason,omitempty"` // Why the booking was cancelled
+}
+
+// IsCancelled reports whether the booking has been cancelled
+func (b Booking) IsCancelled() bool {
+	return b.Status == BookingStatusCancelled
+}
+
+// Waitlist entry statuses
+const (
+	WaitlistStatusWaiting  = "waiting"
+	WaitlistStatusPromoted = "promoted"
+)
+
+// WaitlistEntry represents a member queued for a fully booked class occurrence.
+type WaitlistEntry struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`                          // MongoDB ObjectID
+	ClassID    primitive.ObjectID  `bson:"class_id" json:"class_id"`                         // Reference to the class definition
+	Date       CustomDate          `bson:"date" json:"date"`                                 // Date of the class occurrence
+	MemberID   primitive.ObjectID  `bson:"member_id,omitempty" json:"member_id,omitempty"`   // Reference to the member
+	MemberName string              `bson:"member_name" json:"member_name"`                   // Name of the member
+	Status     string              `bson:"status" json:"status"`                             // waiting or promoted
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`                     // When the member joined the waitlist
+	BookingID  *primitive.ObjectID `bson:"booking_id,omitempty" json:"booking_id,omitempty"` // Booking created on promotion
+	Position   int                 `bson:"-" json:"position,omitempty"`                      // 1-based place in the queue, computed on read
+}
+
+// Member statuses
+const (
+	MemberStatusActive   = "active"
+	MemberStatusInactive = "inactive"
+)
+
+// Member represents a person who can book classes.
+type Member struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`      // MongoDB ObjectID
+	Name      string             `bson:"name" json:"name"`             // Full name of the member
+	Email     string             `bson:"email" json:"email"`           // Unique, stored lowercase
+	Status    string             `bson:"status" json:"status"`         // active or inactive
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"` // When the member was created
+}
+
+// IsActive reports whether the member can book classes
+func (m Member) IsActive() bool {
+	return m.Status == MemberStatusActive
 }
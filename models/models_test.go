@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCustomDateUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"date only", `"2025-03-10"`, "2025-03-10", false},
+		{"timestamp keeps its calendar day", `"2025-03-10T23:30:00-04:00"`, "2025-03-10", false},
+		{"null leaves the date unset", `null`, "", false},
+		{"invalid", `"10/03/2025"`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got CustomDate
+			err := json.Unmarshal([]byte(tt.input), &got)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.expected == "" {
+				assert.True(t, got.IsZero())
+			} else {
+				assert.Equal(t, tt.expected, got.String())
+			}
+		})
+	}
+}
+
+func TestCustomDateBSON(t *testing.T) {
+	type doc struct {
+		Date CustomDate `bson:"date"`
+	}
+
+	t.Run("should store a BSON date at midnight UTC", func(t *testing.T) {
+		local := time.FixedZone("UTC-5", -5*60*60)
+		data, err := bson.Marshal(doc{Date: CustomDate(time.Date(2025, 3, 10, 22, 0, 0, 0, local))})
+		assert.NoError(t, err)
+
+		value := bson.Raw(data).Lookup("date")
+		assert.Equal(t, bson.TypeDateTime, value.Type)
+		assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), value.Time().UTC())
+	})
+
+	t.Run("should round trip", func(t *testing.T) {
+		data, err := bson.Marshal(doc{Date: date(2025, 3, 10)})
+		assert.NoError(t, err)
+
+		var got doc
+		assert.NoError(t, bson.Unmarshal(data, &got))
+		assert.Equal(t, "2025-03-10", got.Date.String())
+	})
+
+	t.Run("should store a zero date as null", func(t *testing.T) {
+		data, err := bson.Marshal(doc{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, bson.TypeNull, bson.Raw(data).Lookup("date").Type)
+	})
+
+	tests := []struct {
+		name     string
+		stored   bson.M
+		expected string
+		wantErr  bool
+	}{
+		{"date string", bson.M{"date": "2025-03-10"}, "2025-03-10", false},
+		{"legacy empty document", bson.M{"date": bson.M{}}, "", false},
+		{"null", bson.M{"date": nil}, "", false},
+		{"unexpected document", bson.M{"date": bson.M{"day": 10}}, "", true},
+		{"unexpected type", bson.M{"date": 20250310}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run("should decode "+tt.name, func(t *testing.T) {
+			data, _ := bson.Marshal(tt.stored)
+
+			var got doc
+			err := bson.Unmarshal(data, &got)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.expected == "" {
+				assert.True(t, got.Date.IsZero())
+			} else {
+				assert.Equal(t, tt.expected, got.Date.String())
+			}
+		})
+	}
+}
@@ -5,4 +5,5 @@ type GlobalResponse struct {
 	Status     string      `json:"status"`
 	Message    string      `json:"message"`
 	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"` // Set on list responses while more pages follow
 }
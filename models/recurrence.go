@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// weekdays maps the day names accepted in a RecurrenceRule to time.Weekday
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// RecurrenceRule describes on which days a class runs between its start and end dates.
+// A class without a rule runs every day.
+type RecurrenceRule struct {
+	Days       []string     `bson:"days" json:"days"`                                 // Days of the week, e.g. ["mon", "wed", "fri"]
+	Exceptions []CustomDate `bson:"exceptions,omitempty" json:"exceptions,omitempty"` // Dates the class does not run, e.g. holidays
+}
+
+// Validate returns the problems with the rule, if any
+func (r RecurrenceRule) Validate() []string {
+	var errs []string
+	if len(r.Days) == 0 {
+		errs = append(errs, "Recurrence days are required")
+	}
+	for _, day := range r.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			errs = append(errs, fmt.Sprintf("Recurrence day %q must be one of mon, tue, wed, thu, fri, sat, sun", day))
+		}
+	}
+	return errs
+}
+
+// matches reports whether the rule schedules a session on the date
+func (r RecurrenceRule) matches(date CustomDate) bool {
+	day := date.String()
+	for _, exception := range r.Exceptions {
+		if exception.String() == day {
+			return false
+		}
+	}
+	weekday := date.Weekday()
+	for _, d := range r.Days {
+		if weekdays[strings.ToLower(d)] == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Occurrence is a concrete session of a class on a given date
+type Occurrence struct {
+	ClassID   primitive.ObjectID `json:"class_id"`
+	Date      CustomDate         `json:"date"`
+	StartsAt  *time.Time         `json:"starts_at,omitempty"` // Only known for classes with a start time
+	EndsAt    *time.Time         `json:"ends_at,omitempty"`
+	Capacity  int                `json:"capacity"`
+	Booked    int                `json:"booked"`
+	Remaining int                `json:"remaining"`
+}
+
+// OccursOn reports whether the class has a session on the date: the date must be
+// within the class range and, for recurring classes, match the recurrence rule
+func (c Class) OccursOn(date CustomDate) bool {
+	if !c.IsActiveOn(date) {
+		return false
+	}
+	return c.Recurrence == nil || c.Recurrence.matches(date)
+}
+
+// Occurrences expands the class into its sessions between from and to, inclusive.
+// Booked counts are left at zero for the caller to fill in.
+func (c Class) Occurrences(from, to CustomDate) []Occurrence {
+	var occurrences []Occurrence
+	for date := from.UTC(); date.String() <= to.String(); date = date.AddDays(1) {
+		if !c.OccursOn(date) {
+			continue
+		}
+		occurrence := Occurrence{ClassID: c.ID, Date: date, Capacity: c.Capacity, Remaining: c.Capacity}
+		if startsAt, endsAt, ok := c.SessionTimes(date); ok {
+			occurrence.StartsAt, occurrence.EndsAt = &startsAt, &endsAt
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
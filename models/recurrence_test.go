@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) CustomDate {
+	return CustomDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func TestRecurrenceRuleValidate(t *testing.T) {
+	t.Run("should accept a valid rule", func(t *testing.T) {
+		rule := RecurrenceRule{Days: []string{"mon", "Wed", "fri"}}
+
+		assert.Empty(t, rule.Validate())
+	})
+
+	t.Run("should report every invalid field", func(t *testing.T) {
+		rule := RecurrenceRule{Days: []string{"monday", "fri", "someday"}}
+
+		assert.Len(t, rule.Validate(), 2)
+	})
+}
+
+func TestClassOccursOn(t *testing.T) {
+	class := Class{
+		StartDate: date(2025, 3, 1),
+		EndDate:   date(2025, 3, 31),
+		Capacity:  10,
+	}
+	recurring := class
+	recurring.Recurrence = &RecurrenceRule{
+		Days:       []string{"mon", "wed", "fri"},
+		Exceptions: []CustomDate{date(2025, 3, 17)},
+	}
+
+	tests := []struct {
+		name     string
+		class    Class
+		date     CustomDate
+		expected bool
+	}{
+		{"daily class runs every day in range", class, date(2025, 3, 4), true},
+		{"daily class does not run before its start", class, date(2025, 2, 28), false},
+		{"recurring class runs on a scheduled weekday", recurring, date(2025, 3, 5), true},
+		{"recurring class does not run on other weekdays", recurring, date(2025, 3, 4), false},
+		{"recurring class does not run on an exception", recurring, date(2025, 3, 17), false},
+		{"recurring class does not run after its end", recurring, date(2025, 4, 2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.class.OccursOn(tt.date))
+		})
+	}
+}
+
+func TestClassOccurrences(t *testing.T) {
+	class := Class{
+		StartDate:       date(2025, 3, 1),
+		EndDate:         date(2025, 3, 31),
+		Capacity:        10,
+		StartTime:       "07:00",
+		DurationMinutes: 45,
+		Timezone:        "Europe/Dublin",
+		Recurrence:      &RecurrenceRule{Days: []string{"mon", "wed", "fri"}},
+	}
+
+	// Dublin switches to summer time on Sunday 30 March and the class ends on the 31st
+	occurrences := class.Occurrences(date(2025, 3, 24), date(2025, 4, 6))
+
+	assert.Len(t, occurrences, 4)
+	assert.Equal(t, "2025-03-24", occurrences[0].Date.String())
+	assert.Equal(t, "2025-03-31", occurrences[3].Date.String())
+	assert.Equal(t, time.Date(2025, 3, 24, 7, 0, 0, 0, time.UTC), occurrences[0].StartsAt.UTC())
+	assert.Equal(t, time.Date(2025, 3, 24, 7, 45, 0, 0, time.UTC), occurrences[0].EndsAt.UTC())
+	assert.Equal(t, time.Date(2025, 3, 31, 6, 0, 0, 0, time.UTC), occurrences[3].StartsAt.UTC())
+	assert.Equal(t, 10, occurrences[0].Remaining)
+
+	t.Run("daily classes have session times too", func(t *testing.T) {
+		daily := class
+		daily.Recurrence = nil
+
+		occurrences := daily.Occurrences(date(2025, 3, 30), date(2025, 4, 2))
+
+		assert.Len(t, occurrences, 2)
+		assert.Equal(t, time.Date(2025, 3, 30, 6, 0, 0, 0, time.UTC), occurrences[0].StartsAt.UTC())
+	})
+
+	t.Run("classes without a start time have no session times", func(t *testing.T) {
+		untimed := class
+		untimed.StartTime, untimed.DurationMinutes, untimed.Timezone = "", 0, ""
+
+		occurrences := untimed.Occurrences(date(2025, 3, 24), date(2025, 3, 24))
+
+		assert.Len(t, occurrences, 1)
+		assert.Nil(t, occurrences[0].StartsAt)
+	})
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+)
+
+const sessionTimeFormat = "15:04"
+
+// ValidateSchedule returns the problems with the time of day of the class, if any.
+// The time of day is optional, but a start time needs a duration and a timezone.
+func (c Class) ValidateSchedule() []string {
+	if c.StartTime == "" && c.DurationMinutes == 0 && c.Timezone == "" {
+		return nil
+	}
+	var errs []string
+	if _, err := time.Parse(sessionTimeFormat, c.StartTime); err != nil {
+		errs = append(errs, "Start time must be formatted as HH:MM")
+	}
+	if c.DurationMinutes <= 0 {
+		errs = append(errs, "Duration must be greater than 0")
+	}
+	if _, err := time.LoadLocation(c.Timezone); c.Timezone == "" || err != nil {
+		errs = append(errs, "Timezone must be a valid IANA timezone")
+	}
+	return errs
+}
+
+// HasStartTime reports whether the sessions of the class have a time of day
+func (c Class) HasStartTime() bool {
+	return c.StartTime != ""
+}
+
+// location returns the timezone of the class sessions
+func (c Class) location() (*time.Location, bool) {
+	loc, err := time.LoadLocation(c.Timezone)
+	return loc, err == nil
+}
+
+// SessionTimes returns when the session on the date starts and ends. ok is false for
+// classes without a valid time of day. It does not check that the class runs on the date.
+func (c Class) SessionTimes(date CustomDate) (start time.Time, end time.Time, ok bool) {
+	if !c.HasStartTime() {
+		return time.Time{}, time.Time{}, false
+	}
+	loc, ok := c.location()
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	clock, err := time.Parse(sessionTimeFormat, c.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	t := date.ToTime()
+	start = time.Date(t.Year(), t.Month(), t.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	return start, start.Add(time.Duration(c.DurationMinutes) * time.Minute), true
+}
+
+// SessionDate returns the date of the session starting at the instant. ok is false if
+// no session of the class starts at that instant.
+func (c Class) SessionDate(startsAt time.Time) (CustomDate, bool) {
+	loc, ok := c.location()
+	if !c.HasStartTime() || !ok {
+		return CustomDate{}, false
+	}
+	local := startsAt.In(loc)
+	date := CustomDate(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC))
+	start, _, ok := c.SessionTimes(date)
+	if !ok || !start.Equal(startsAt) || !c.OccursOn(date) {
+		return CustomDate{}, false
+	}
+	return date, true
+}
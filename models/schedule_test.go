@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassValidateSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    Class
+		expected int
+	}{
+		{"no time of day is valid", Class{}, 0},
+		{"complete time of day is valid", Class{StartTime: "18:30", DurationMinutes: 60, Timezone: "Europe/Dublin"}, 0},
+		{"start time without duration and timezone", Class{StartTime: "18:30"}, 2},
+		{"every field invalid", Class{StartTime: "6pm", DurationMinutes: -5, Timezone: "Mars/Olympus"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, tt.class.ValidateSchedule(), tt.expected)
+		})
+	}
+}
+
+func TestClassSessionDate(t *testing.T) {
+	class := Class{
+		StartDate:       date(2025, 3, 1),
+		EndDate:         date(2025, 3, 31),
+		StartTime:       "23:30",
+		DurationMinutes: 60,
+		Timezone:        "America/New_York",
+		Recurrence:      &RecurrenceRule{Days: []string{"mon"}},
+	}
+
+	t.Run("should map a session start to its local date", func(t *testing.T) {
+		// 23:30 in New York on Monday 10 March is already Tuesday in UTC
+		got, ok := class.SessionDate(time.Date(2025, 3, 11, 3, 30, 0, 0, time.UTC))
+
+		assert.True(t, ok)
+		assert.Equal(t, "2025-03-10", got.String())
+	})
+
+	t.Run("should reject an instant that is not a session start", func(t *testing.T) {
+		_, ok := class.SessionDate(time.Date(2025, 3, 11, 4, 0, 0, 0, time.UTC))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject a day the class does not run", func(t *testing.T) {
+		_, ok := class.SessionDate(time.Date(2025, 3, 12, 3, 30, 0, 0, time.UTC))
+
+		assert.False(t, ok)
+	})
+}
@@ -1,38 +1,58 @@
 package routes
 
 import (
+	"context"
+	"encoding/json"
 	"log"
 	"net/http"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/sinhaseemant/glofox-backend/api"
 	"github.com/sinhaseemant/glofox-backend/internal/handlers"
+	"github.com/sinhaseemant/glofox-backend/internal/health"
+	"github.com/sinhaseemant/glofox-backend/internal/metrics"
+	"github.com/sinhaseemant/glofox-backend/internal/service"
 	"github.com/sinhaseemant/glofox-backend/internal/storage"
+	"github.com/sinhaseemant/glofox-backend/internal/tracing"
+	"github.com/sinhaseemant/glofox-backend/util"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Options configures the router
+type Options struct {
+	AllowedOrigins []string       // Origins allowed to make cross-origin requests, "*" for any
+	Probes         *health.Probes // Serves /livez and /readyz
+}
+
 // NewRouter sets up the chi router and registers routes
-func NewRouter(repo *storage.MongoRepository) *chi.Mux {
+func NewRouter(backend *storage.Backend, opts Options) *chi.Mux {
 	r := chi.NewRouter()
 
+	// The OpenAPI spec names the operations and is served at /swagger.json
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI spec: %s", err)
+	}
+	operation := operationName(swagger)
+
 	// Middleware
+	r.Use(tracing.Middleware(operation)) // Starts a span per request
+	r.Use(traceRequestID)
 	r.Use(middleware.Logger) // Logs requests
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"}, // Adjust as needed
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedOrigins:   opts.AllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "traceparent", "tracestate"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300, // Maximum value not ignored by any major browsers
 	})) // Enable CORS
-	// Serve OpenAPI JSON at /swagger.json
-	swagger, err := api.GetSwagger()
-	if err != nil {
-		log.Fatalf("Failed to load OpenAPI spec: %s", err)
-	}
 
+	// Serve OpenAPI JSON at /swagger.json
 	r.Get("/swagger.json", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -48,19 +68,64 @@ func NewRouter(repo *storage.MongoRepository) *chi.Mux {
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger.json"), // Points to OpenAPI JSON spec
 	))
-	// Health Check
+	// Health Check, kept for the clients polling it. It only tells the process is up, like /livez.
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("OK"))
 	})
-	// Inject repository into API handlers
-	// Get Database instance
-	cr := storage.NewClassRepository(repo.Client.Database("classes"))
-	ch := handlers.NewClassHandler(cr)
-	br := storage.NewBookingRepository(repo.Client.Database("bookings"))
-	bh := handlers.NewBookingHandler(br)
-	si := api.NewServerInterface(repo, ch, bh)
+	// Liveness and readiness probes
+	r.Get("/livez", opts.Probes.Live)
+	r.Get("/readyz", opts.Probes.Ready)
+	// Prometheus metrics
+	r.Handle("/metrics", metrics.Handler())
+	// Inject the storage backend's repositories into API handlers
+	ch := handlers.NewClassHandler(service.NewClassService(backend.Classes, backend.Bookings, backend.Waitlist))
+	bh := handlers.NewBookingHandler(service.NewBookingService(backend.Bookings, backend.Classes, backend.Members, backend.Waitlist))
+	mh := handlers.NewMemberHandler(service.NewMemberService(backend.Members))
+	si := api.NewServerInterface(ch, bh, mh)
 
-	r.Mount("/", api.Handler(si))
+	r.Mount("/", api.HandlerWithOptions(si, api.ChiServerOptions{
+		Middlewares:      []api.MiddlewareFunc{metrics.Middleware(operation)},
+		ErrorHandlerFunc: metrics.ErrorHandler(operation, invalidParameter),
+	}))
 
 	return r
 }
+
+// traceRequestID makes the trace ID of a request its request ID, which the request logger
+// prints, so the log line of a request leads to its trace
+func traceRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+			r = r.WithContext(context.WithValue(r.Context(), middleware.RequestIDKey, sc.TraceID().String()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// invalidParameter answers a request whose path or query parameters cannot be read
+func invalidParameter(w http.ResponseWriter, r *http.Request, err error) {
+	resStr, _ := json.Marshal(util.SendGlobalResponse(util.StatusFailed, nil, http.StatusBadRequest, err))
+	http.Error(w, string(resStr), http.StatusBadRequest)
+}
+
+// operationName returns a function naming the OpenAPI operation served to a request by its
+// operationId, and other routes by method and pattern. The route is only known once the
+// request is matched.
+func operationName(swagger *openapi3.T) func(r *http.Request) string {
+	operations := map[string]string{} // By method and path, as "GET /classes/{id}"
+	for path, item := range swagger.Paths.Map() {
+		for method, operation := range item.Operations() {
+			operations[method+" "+path] = operation.OperationID
+		}
+	}
+	return func(r *http.Request) string {
+		pattern := chi.RouteContext(r.Context()).RoutePattern()
+		if operation, ok := operations[r.Method+" "+pattern]; ok {
+			return operation
+		}
+		if pattern == "" {
+			return r.Method // Not found
+		}
+		return r.Method + " " + pattern
+	}
+}
@@ -3,19 +3,19 @@ package routes
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/sinhaseemant/glofox-backend/internal/health"
 	"github.com/sinhaseemant/glofox-backend/internal/storage"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestNewRouter(t *testing.T) {
-	// Mock MongoRepository
-	mockClient := &mongo.Client{} // Replace with a mock or stub if needed
-	mockRepo := &storage.MongoRepository{Client: mockClient}
-
-	// Create the router
-	router := NewRouter(mockRepo)
+	// Create the router on an in-memory backend, no database needed
+	router := NewRouter(storage.NewMemoryBackend(storage.NewMemoryStore()), Options{
+		AllowedOrigins: []string{"*"},
+		Probes:         health.NewProbes("test", nil),
+	})
 
 	// Define test cases
 	tests := []struct {
@@ -30,12 +30,42 @@ func TestNewRouter(t *testing.T) {
 			path:       "/health",
 			statusCode: http.StatusOK,
 		},
+		{
+			name:       "Liveness",
+			method:     http.MethodGet,
+			path:       "/livez",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Readiness",
+			method:     http.MethodGet,
+			path:       "/readyz",
+			statusCode: http.StatusOK,
+		},
 		{
 			name:       "Swagger JSON",
 			method:     http.MethodGet,
 			path:       "/swagger.json",
 			statusCode: http.StatusOK,
 		},
+		{
+			name:       "List classes",
+			method:     http.MethodGet,
+			path:       "/classes",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "List bookings",
+			method:     http.MethodGet,
+			path:       "/bookings?status=active",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Missing member",
+			method:     http.MethodGet,
+			path:       "/members/67eacd9f4aed3932a6d966a3",
+			statusCode: http.StatusNotFound,
+		},
 	}
 
 	// Run test cases
@@ -55,3 +85,30 @@ func TestNewRouter(t *testing.T) {
 		})
 	}
 }
+
+func TestMetrics(t *testing.T) {
+	router := NewRouter(storage.NewMemoryBackend(storage.NewMemoryStore()), Options{
+		AllowedOrigins: []string{"*"},
+		Probes:         health.NewProbes("test", nil),
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/classes/67eacd9f4aed3932a6d966a3", nil))
+	invalid := httptest.NewRecorder()
+	router.ServeHTTP(invalid, httptest.NewRequest(http.MethodGet, "/classes?limit=abc", nil))
+	if invalid.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, invalid.Code)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	// Requests are labelled by the operationId of their route
+	if want := `glofox_http_requests_total{operation="GetClass",status="404"}`; !strings.Contains(rr.Body.String(), want) {
+		t.Errorf("Expected the metrics to contain %s", want)
+	}
+	// Including the requests whose parameters cannot be read
+	if want := `glofox_http_requests_total{operation="GetClasses",status="400"}`; !strings.Contains(rr.Body.String(), want) {
+		t.Errorf("Expected the metrics to contain %s", want)
+	}
+}